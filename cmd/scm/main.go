@@ -0,0 +1,708 @@
+/*
+Command scm checks out repositories described by ExternalCheckoutOptions.
+
+	scm checkout [options] <path>
+	scm validate [options]
+	scm convert [options] [-to json|yaml|flags] [-api-version v1|v2]
+	scm url [options]
+	scm serve [-addr address] [-workspace path] [-max-concurrent-checkouts n] [-timeout duration] [-allowed-types types]
+
+The checkout options are read from a JSON or YAML file with -f, from environment variables
+with -env-prefix (see scm.CheckoutOptionsFromEnv), or from a clone URL with -repo-url.
+Every ExternalCheckoutOptions field also has a flag, named after its JSON name with
+underscores replaced by dashes, that overrides the value read. Secrets are only read
+from files, with -private-key-file, -access-token-file, -password-file,
+-secret-access-key-file and -session-token-file, where - is stdin.
+
+checkout prints the CheckoutResult as JSON, and url prints the CheckoutRemoteURLs as JSON.
+serve runs the HTTP checkout service from scm.NewHandler.
+The exit code is 2 for usage errors, 3 for validation errors, 4 for authentication
+failures, 5 for network failures, and 1 for any other error.
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peter-edge/go-scm"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	exitCodeError      = 1
+	exitCodeUsage      = 2
+	exitCodeValidation = 3
+	exitCodeAuth       = 4
+	exitCodeNetwork    = 5
+
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+	formatFlags = "flags"
+
+	// request bodies are small JSON documents, so reading them is not bounded by the checkout timeout
+	serveReadHeaderTimeout = 10 * time.Second
+	serveReadTimeout       = time.Minute
+	serveIdleTimeout       = 2 * time.Minute
+)
+
+var (
+	// secret fields are read from the file named by the flag <name>-file
+	secretFieldNames = map[string]bool{
+		"Password":        true,
+		"PrivateKey":      true,
+		"AccessToken":     true,
+		"SecretAccessKey": true,
+		"SessionToken":    true,
+	}
+
+	// matched against the lowercased stderr of the failed git, svn, hg or fossil command, which
+	// follows its exit status in the error
+	commandErrorRegexp = regexp.MustCompile(`exit status [0-9]+ (?s)(.*)$`)
+	authErrorMessages  = []string{
+		// ssh, as in permission denied (publickey)
+		"permission denied (",
+		"host key verification failed",
+		"authentication failed",
+		"authentication required",
+		"could not read username",
+		"could not read password",
+		"authorization failed",
+		"access denied",
+		"returned error: 401",
+		"returned error: 403",
+		"http error 401",
+		"http error 403",
+		"login failed",
+		"invalid credentials",
+	}
+	networkErrorMessages = []string{
+		"could not resolve host",
+		"unable to look up",
+		"name or service not known",
+		"temporary failure in name resolution",
+		"connection refused",
+		"connection timed out",
+		"connection reset",
+		"operation timed out",
+		"network is unreachable",
+		"no route to host",
+		"the remote end hung up unexpectedly",
+		"unable to connect",
+	}
+
+	commands = map[string]func(*env, []string) error{
+		"checkout": checkoutCommand,
+		"validate": validateCommand,
+		"convert":  convertCommand,
+		"url":      urlCommand,
+		"serve":    serveCommand,
+	}
+
+	// the flag package prints the error and usage itself
+	errFlagsNotParsed = errors.New("flags not parsed")
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+type env struct {
+	stdin     io.Reader
+	stdout    io.Writer
+	stderr    io.Writer
+	stdinUsed bool
+}
+
+type usageError struct {
+	message string
+}
+
+func newUsageError(format string, args ...interface{}) error {
+	return &usageError{fmt.Sprintf(format, args...)}
+}
+
+func (u *usageError) Error() string {
+	return u.message
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	e := &env{
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitCodeUsage
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "scm: unknown command %q\n", args[0])
+		printUsage(stderr)
+		return exitCodeUsage
+	}
+	if err := command(e, args[1:]); err != nil {
+		if err == errFlagsNotParsed {
+			return exitCodeUsage
+		}
+		printError(stderr, err)
+		return getExitCode(err)
+	}
+	return 0
+}
+
+func checkoutCommand(e *env, args []string) error {
+	flagSet, input := newFlagSet(e, "checkout", "<path>")
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+	if flagSet.NArg() != 1 {
+		return newUsageError("checkout requires exactly one path")
+	}
+	absolutePath, err := filepath.Abs(flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	checkoutOptions, err := input.getCheckoutOptions()
+	if err != nil {
+		return err
+	}
+	checkoutResult, err := scm.Checkout(checkoutOptions, absolutePath)
+	if err != nil {
+		return err
+	}
+	return writeJSON(e.stdout, checkoutResult)
+}
+
+func validateCommand(e *env, args []string) error {
+	flagSet, input := newFlagSet(e, "validate", "")
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+	if flagSet.NArg() != 0 {
+		return newUsageError("validate takes no arguments")
+	}
+	_, err := input.getCheckoutOptions()
+	return err
+}
+
+func convertCommand(e *env, args []string) error {
+	flagSet, input := newFlagSet(e, "convert", "")
+	to := flagSet.String("to", formatJSON, "the output format, one of json, yaml or flags")
+	apiVersion := flagSet.String("api-version", scm.ExternalAPIVersionV1, "the API version of json and yaml output, one of v1 or v2")
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+	if flagSet.NArg() != 0 {
+		return newUsageError("convert takes no arguments")
+	}
+	checkoutOptions, err := input.getCheckoutOptions()
+	if err != nil {
+		return err
+	}
+	if *to == formatFlags {
+		externalCheckoutOptions, err := scm.ConvertCheckoutOptions(checkoutOptions)
+		if err != nil {
+			return err
+		}
+		return writeFlags(e, externalCheckoutOptions)
+	}
+	var output interface{}
+	switch *apiVersion {
+	case scm.ExternalAPIVersionV1:
+		output, err = scm.ConvertCheckoutOptions(checkoutOptions)
+	case scm.ExternalAPIVersionV2:
+		output, err = scm.ConvertCheckoutOptionsV2(checkoutOptions)
+	default:
+		return newUsageError("unknown API version %q", *apiVersion)
+	}
+	if err != nil {
+		return err
+	}
+	switch *to {
+	case formatJSON:
+		return writeJSON(e.stdout, output)
+	case formatYAML:
+		data, err := yaml.Marshal(output)
+		if err != nil {
+			return err
+		}
+		_, err = e.stdout.Write(data)
+		return err
+	default:
+		return newUsageError("unknown output format %q", *to)
+	}
+}
+
+func urlCommand(e *env, args []string) error {
+	flagSet, input := newFlagSet(e, "url", "")
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+	if flagSet.NArg() != 0 {
+		return newUsageError("url takes no arguments")
+	}
+	checkoutOptions, err := input.getCheckoutOptions()
+	if err != nil {
+		return err
+	}
+	checkoutRemoteURLs, err := scm.RemoteURLs(checkoutOptions)
+	if err != nil {
+		return err
+	}
+	return writeJSON(e.stdout, checkoutRemoteURLs)
+}
+
+func serveCommand(e *env, args []string) error {
+	flagSet := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flagSet.SetOutput(e.stderr)
+	addr := flagSet.String("addr", ":8080", "the address to listen on")
+	workspace := flagSet.String("workspace", "", "the directory to check out in, a temporary directory if not set")
+	maxConcurrentCheckouts := flagSet.Int("max-concurrent-checkouts", 0, "the number of checkouts that can run at once, 4 if not set")
+	timeout := flagSet.Duration("timeout", 0, "the time a request can take, 10m if not set")
+	allowedTypes := flagSet.String("allowed-types", "", "a comma-separated list of the types that can be checked out, all but local if not set")
+	if err := parseFlags(flagSet, args); err != nil {
+		return err
+	}
+	if flagSet.NArg() != 0 {
+		return newUsageError("serve takes no arguments")
+	}
+	handlerOptions := &scm.HandlerOptions{
+		WorkspaceDirPath:       *workspace,
+		MaxConcurrentCheckouts: *maxConcurrentCheckouts,
+		Timeout:                *timeout,
+	}
+	if *allowedTypes != "" {
+		for _, allowedType := range strings.Split(*allowedTypes, ",") {
+			checkoutOptionsType, err := scm.CheckoutOptionsTypeOf(strings.TrimSpace(allowedType))
+			if err != nil {
+				return newUsageError("%v", err)
+			}
+			handlerOptions.AllowedTypes = append(handlerOptions.AllowedTypes, checkoutOptionsType)
+		}
+	}
+	if handlerOptions.WorkspaceDirPath == "" {
+		workspaceDirPath, err := ioutil.TempDir("", "scm-serve")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(workspaceDirPath)
+		handlerOptions.WorkspaceDirPath = workspaceDirPath
+	}
+	handler, err := scm.NewHandler(handlerOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "scm: serving on %s with workspace %s\n", *addr, handlerOptions.WorkspaceDirPath)
+	return newServer(*addr, handler).ListenAndServe()
+}
+
+// newServer returns the server for serve. WriteTimeout is not set, since archives are streamed
+// for as long as the client reads them, after the checkout that HandlerOptions.Timeout bounds.
+func newServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: serveReadHeaderTimeout,
+		ReadTimeout:       serveReadTimeout,
+		IdleTimeout:       serveIdleTimeout,
+	}
+}
+
+// externalFlag is a flag for a field of ExternalCheckoutOptions, or of one of its blocks
+// such as ExternalSecurityOptions.
+type externalFlag struct {
+	name      string
+	fieldName string
+	// blockFieldName is the name of the ExternalCheckoutOptions field of the block, or empty.
+	blockFieldName string
+	secret         bool
+	boolean        bool
+	integer        bool
+	list           bool
+}
+
+// stringsFlag is a flag that can be set more than once, such as -sparse-paths.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+type input struct {
+	env        *env
+	flagSet    *flag.FlagSet
+	file       string
+	format     string
+	envPrefix  string
+	repoURL    string
+	stringVars map[string]*string
+	boolVars   map[string]*bool
+	intVars    map[string]*int
+	listVars   map[string]*stringsFlag
+}
+
+func newFlagSet(e *env, name string, arguments string) (*flag.FlagSet, *input) {
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	flagSet.SetOutput(e.stderr)
+	flagSet.Usage = func() {
+		fmt.Fprintln(e.stderr, strings.TrimSpace(fmt.Sprintf("usage: scm %s [options] %s", name, arguments)))
+		flagSet.PrintDefaults()
+	}
+	i := &input{
+		env:        e,
+		flagSet:    flagSet,
+		stringVars: make(map[string]*string),
+		boolVars:   make(map[string]*bool),
+		intVars:    make(map[string]*int),
+		listVars:   make(map[string]*stringsFlag),
+	}
+	flagSet.StringVar(&i.file, "f", "", "a JSON or YAML ExternalCheckoutOptions file, or - for stdin")
+	flagSet.StringVar(&i.format, "format", "", "the format of -f, one of json or yaml, detected if not set")
+	flagSet.StringVar(&i.envPrefix, "env-prefix", "", "read the checkout options from environment variables with this prefix")
+	flagSet.StringVar(&i.repoURL, "repo-url", "", "read the checkout options from a clone URL")
+	for _, externalFlag := range getExternalFlags() {
+		switch {
+		case externalFlag.boolean:
+			i.boolVars[externalFlag.name] = flagSet.Bool(externalFlag.name, false, "sets "+externalFlag.fieldName)
+		case externalFlag.integer:
+			i.intVars[externalFlag.name] = flagSet.Int(externalFlag.name, 0, "sets "+externalFlag.fieldName)
+		case externalFlag.list:
+			i.listVars[externalFlag.name] = &stringsFlag{}
+			flagSet.Var(i.listVars[externalFlag.name], externalFlag.name, "adds to "+externalFlag.fieldName+", can be repeated")
+		case externalFlag.secret:
+			i.stringVars[externalFlag.name] = flagSet.String(externalFlag.name, "", "reads "+externalFlag.fieldName+" from a file, or - for stdin")
+		default:
+			i.stringVars[externalFlag.name] = flagSet.String(externalFlag.name, "", "sets "+externalFlag.fieldName)
+		}
+	}
+	return flagSet, i
+}
+
+func parseFlags(flagSet *flag.FlagSet, args []string) error {
+	if err := flagSet.Parse(args); err != nil {
+		return errFlagsNotParsed
+	}
+	return nil
+}
+
+func (i *input) getCheckoutOptions() (scm.CheckoutOptions, error) {
+	externalCheckoutOptions, err := i.getExternalCheckoutOptions()
+	if err != nil {
+		return nil, err
+	}
+	return scm.ConvertExternalCheckoutOptions(externalCheckoutOptions)
+}
+
+func (i *input) getExternalCheckoutOptions() (*scm.ExternalCheckoutOptions, error) {
+	numSources := 0
+	for _, source := range []string{i.file, i.envPrefix, i.repoURL} {
+		if source != "" {
+			numSources++
+		}
+	}
+	if numSources > 1 {
+		return nil, newUsageError("only one of -f, -env-prefix and -repo-url can be set")
+	}
+	externalCheckoutOptions := &scm.ExternalCheckoutOptions{}
+	var err error
+	switch {
+	case i.file != "":
+		externalCheckoutOptions, err = i.readExternalCheckoutOptions()
+	case i.envPrefix != "":
+		externalCheckoutOptions, err = scm.ExternalCheckoutOptionsFromEnv(i.envPrefix)
+	case i.repoURL != "":
+		var checkoutOptions scm.CheckoutOptions
+		checkoutOptions, err = scm.ParseRemoteURL(i.repoURL)
+		if err == nil {
+			externalCheckoutOptions, err = scm.ConvertCheckoutOptions(checkoutOptions)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	setFlags := make(map[string]bool)
+	i.flagSet.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+	for _, externalFlag := range getExternalFlags() {
+		if !setFlags[externalFlag.name] {
+			continue
+		}
+		value, _ := getExternalFlagStruct(externalCheckoutOptions, externalFlag, true)
+		field := value.FieldByName(externalFlag.fieldName)
+		switch {
+		case externalFlag.boolean:
+			field.SetBool(*i.boolVars[externalFlag.name])
+		case externalFlag.integer:
+			field.SetInt(int64(*i.intVars[externalFlag.name]))
+		case externalFlag.list:
+			field.Set(reflect.ValueOf([]string(*i.listVars[externalFlag.name])))
+		case externalFlag.secret:
+			secret, err := i.readSecret(*i.stringVars[externalFlag.name], externalFlag.fieldName != "PrivateKey")
+			if err != nil {
+				return nil, err
+			}
+			field.SetString(secret)
+		default:
+			field.SetString(*i.stringVars[externalFlag.name])
+		}
+	}
+	return externalCheckoutOptions, nil
+}
+
+func (i *input) readExternalCheckoutOptions() (*scm.ExternalCheckoutOptions, error) {
+	data, err := i.readFile(i.file)
+	if err != nil {
+		return nil, err
+	}
+	format := i.format
+	if format == "" {
+		format = getFormat(i.file, data)
+	}
+	// the API version is read on its own first, so unknown fields are only checked when the whole document is read
+	var unmarshal func(interface{}) error
+	var strictUnmarshal func(interface{}) error
+	switch format {
+	case formatJSON:
+		unmarshal = func(value interface{}) error {
+			return json.Unmarshal(data, value)
+		}
+		strictUnmarshal = func(value interface{}) error {
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.DisallowUnknownFields()
+			return decoder.Decode(value)
+		}
+	case formatYAML:
+		unmarshal = func(value interface{}) error {
+			return yaml.Unmarshal(data, value)
+		}
+		strictUnmarshal = func(value interface{}) error {
+			return yaml.UnmarshalStrict(data, value)
+		}
+	default:
+		return nil, newUsageError("unknown input format %q", format)
+	}
+	externalCheckoutOptionsV2, err := scm.ReadExternalCheckoutOptions(
+		func(value interface{}) error {
+			switch value.(type) {
+			case *scm.ExternalCheckoutOptions, *scm.ExternalCheckoutOptionsV2:
+				return strictUnmarshal(value)
+			default:
+				return unmarshal(value)
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scm.DowngradeExternalCheckoutOptions(externalCheckoutOptionsV2)
+}
+
+func (i *input) readSecret(filePath string, trimSpace bool) (string, error) {
+	data, err := i.readFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	if trimSpace {
+		return strings.TrimSpace(string(data)), nil
+	}
+	return string(data), nil
+}
+
+func (i *input) readFile(filePath string) ([]byte, error) {
+	if filePath != "-" {
+		return ioutil.ReadFile(filePath)
+	}
+	if i.env.stdinUsed {
+		return nil, newUsageError("stdin can only be read once")
+	}
+	i.env.stdinUsed = true
+	return ioutil.ReadAll(i.env.stdin)
+}
+
+func getFormat(filePath string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return formatJSON
+	case ".yaml", ".yml":
+		return formatYAML
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return formatJSON
+	}
+	return formatYAML
+}
+
+func getExternalFlags() []*externalFlag {
+	var externalFlags []*externalFlag
+	externalFlags = append(externalFlags, getStructExternalFlags(reflect.TypeOf(scm.ExternalCheckoutOptions{}), "", "")...)
+	externalFlags = append(externalFlags, getStructExternalFlags(reflect.TypeOf(scm.ExternalSecurityOptions{}), "SecurityOptions", "")...)
+	externalFlags = append(externalFlags, getStructExternalFlags(reflect.TypeOf(scm.ExternalSubmoduleOptions{}), "Submodules", "submodules-")...)
+	return append(externalFlags, getStructExternalFlags(reflect.TypeOf(scm.ExternalLFSOptions{}), "LFS", "lfs-")...)
+}
+
+func getStructExternalFlags(structType reflect.Type, blockFieldName string, prefix string) []*externalFlag {
+	var externalFlags []*externalFlag
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		list := field.Type == reflect.TypeOf([]string(nil))
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool, reflect.Int:
+		default:
+			if !list {
+				continue
+			}
+		}
+		name := prefix + strings.Replace(strings.Split(field.Tag.Get("json"), ",")[0], "_", "-", -1)
+		if blockFieldName == "SecurityOptions" && name == "type" {
+			name = "security-type"
+		}
+		secret := secretFieldNames[field.Name]
+		if secret {
+			name = name + "-file"
+		}
+		externalFlags = append(externalFlags, &externalFlag{
+			name:           name,
+			fieldName:      field.Name,
+			blockFieldName: blockFieldName,
+			secret:         secret,
+			boolean:        field.Type.Kind() == reflect.Bool,
+			integer:        field.Type.Kind() == reflect.Int,
+			list:           list,
+		})
+	}
+	return externalFlags
+}
+
+// getExternalFlagStruct returns the struct that holds the field of externalFlag. If the field is in a
+// block that is not set, the block is created if create is true, and false is returned otherwise.
+func getExternalFlagStruct(externalCheckoutOptions *scm.ExternalCheckoutOptions, externalFlag *externalFlag, create bool) (reflect.Value, bool) {
+	value := reflect.ValueOf(externalCheckoutOptions).Elem()
+	if externalFlag.blockFieldName == "" {
+		return value, true
+	}
+	block := value.FieldByName(externalFlag.blockFieldName)
+	if block.IsNil() {
+		if !create {
+			return reflect.Value{}, false
+		}
+		block.Set(reflect.New(block.Type().Elem()))
+	}
+	return block.Elem(), true
+}
+
+// writeFlags writes the flags that produce externalCheckoutOptions. Secrets can only
+// be given as files, so they are left out with a warning.
+func writeFlags(e *env, externalCheckoutOptions *scm.ExternalCheckoutOptions) error {
+	var args []string
+	var omitted []string
+	for _, externalFlag := range getExternalFlags() {
+		value, ok := getExternalFlagStruct(externalCheckoutOptions, externalFlag, false)
+		if !ok {
+			continue
+		}
+		field := value.FieldByName(externalFlag.fieldName)
+		switch {
+		case externalFlag.boolean:
+			if field.Bool() {
+				args = append(args, "-"+externalFlag.name)
+			}
+		case externalFlag.integer:
+			if field.Int() != 0 {
+				args = append(args, "-"+externalFlag.name+"="+strconv.FormatInt(field.Int(), 10))
+			}
+		case externalFlag.list:
+			for _, element := range field.Interface().([]string) {
+				args = append(args, "-"+externalFlag.name+"="+quoteShell(element))
+			}
+		case field.String() == "":
+		case externalFlag.secret:
+			omitted = append(omitted, "-"+externalFlag.name)
+		default:
+			args = append(args, "-"+externalFlag.name+"="+quoteShell(field.String()))
+		}
+	}
+	if len(omitted) > 0 {
+		sort.Strings(omitted)
+		fmt.Fprintf(e.stderr, "scm: secrets are not included, set %s\n", strings.Join(omitted, ", "))
+	}
+	_, err := fmt.Fprintln(e.stdout, strings.Join(args, " "))
+	return err
+}
+
+func quoteShell(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:@+=,", r))
+	}) == -1 {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+func writeJSON(writer io.Writer, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(writer, string(data))
+	return err
+}
+
+func printError(writer io.Writer, err error) {
+	if validationErrors, ok := err.(scm.ValidationErrors); ok {
+		for _, validationError := range validationErrors {
+			fmt.Fprintln(writer, validationError.Error())
+		}
+		return
+	}
+	fmt.Fprintln(writer, err.Error())
+}
+
+func printUsage(writer io.Writer) {
+	fmt.Fprintln(writer, "usage: scm checkout|validate|convert|url|serve [options]")
+}
+
+func getExitCode(err error) int {
+	if _, ok := err.(*usageError); ok {
+		return exitCodeUsage
+	}
+	if _, ok := err.(scm.ValidationErrors); ok {
+		return exitCodeValidation
+	}
+	// local errors such as permission denied on a file are not authentication or network failures
+	matches := commandErrorRegexp.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return exitCodeError
+	}
+	message := strings.ToLower(matches[1])
+	for _, authErrorMessage := range authErrorMessages {
+		if strings.Contains(message, authErrorMessage) {
+			return exitCodeAuth
+		}
+	}
+	for _, networkErrorMessage := range networkErrorMessages {
+		if strings.Contains(message, networkErrorMessage) {
+			return exitCodeNetwork
+		}
+	}
+	return exitCodeError
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/peter-edge/go-scm"
+)
+
+const (
+	testGithubJSON = `{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854"}`
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	dirPath := newTestDir(t)
+	defer os.RemoveAll(dirPath)
+	validFile := writeTestFile(t, dirPath, "valid.json", testGithubJSON)
+	invalidFile := writeTestFile(t, dirPath, "invalid.yaml", "type: github\nuser: peter-edge\ncommit_id: master\n")
+	unknownFieldFile := writeTestFile(t, dirPath, "unknown.json", `{"type": "github", "banana": "yes"}`)
+	for _, testCase := range []struct {
+		args             []string
+		expectedExitCode int
+		expectedStderr   string
+	}{
+		{[]string{"validate", "-f", validFile}, 0, ""},
+		{[]string{"validate", "-f", invalidFile}, exitCodeValidation, "Repository"},
+		{[]string{"validate", "-f", validFile, "-commit-id", "master"}, exitCodeValidation, "CommitID"},
+		{[]string{"validate", "-f", unknownFieldFile}, exitCodeError, "banana"},
+		{[]string{"validate", "-repo-url", "https://github.com/peter-edge/smartystreets_ruby.git", "-branch", "master", "-commit-id", "a40e854"}, 0, ""},
+		{[]string{"validate", "-f", validFile, "-repo-url", "https://github.com/peter-edge/smartystreets_ruby.git"}, exitCodeUsage, "only one"},
+		{[]string{"validate", "-banana"}, exitCodeUsage, "banana"},
+		{[]string{"validate", "extra"}, exitCodeUsage, "no arguments"},
+		{[]string{"banana"}, exitCodeUsage, "unknown command"},
+		{nil, exitCodeUsage, "usage"},
+	} {
+		_, stderr, exitCode := runTest(testCase.args, "")
+		if exitCode != testCase.expectedExitCode {
+			t.Errorf("%v: expected exit code %d, got %d: %s", testCase.args, testCase.expectedExitCode, exitCode, stderr)
+		}
+		if !strings.Contains(stderr, testCase.expectedStderr) {
+			t.Errorf("%v: expected stderr to contain %q, got %q", testCase.args, testCase.expectedStderr, stderr)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+	dirPath := newTestDir(t)
+	defer os.RemoveAll(dirPath)
+	tokenFile := writeTestFile(t, dirPath, "token", "token\n")
+	jsonOutput, stderr, exitCode := runTest([]string{"convert", "-f", "-", "-access-token-file", tokenFile, "-security-type", "accessToken", "-sparse-paths", "lib", "-sparse-paths", "spec", "-submodules-shallow", "-submodules-jobs", "8", "-lfs-include", "assets"}, testGithubJSON)
+	if exitCode != 0 {
+		t.Fatalf("%d: %s", exitCode, stderr)
+	}
+	expected := &scm.ExternalCheckoutOptions{
+		Type:        "github",
+		User:        "peter-edge",
+		Repository:  "smartystreets_ruby",
+		Branch:      "master",
+		CommitID:    "a40e854",
+		SparsePaths: []string{"lib", "spec"},
+		Submodules: &scm.ExternalSubmoduleOptions{
+			Shallow: true,
+			Jobs:    8,
+		},
+		LFS: &scm.ExternalLFSOptions{
+			Include: []string{"assets"},
+		},
+		SecurityOptions: &scm.ExternalSecurityOptions{
+			Type:        "accessToken",
+			AccessToken: "token",
+		},
+	}
+	checkTestExternalCheckoutOptions(t, expected, jsonOutput)
+
+	yamlOutput, stderr, exitCode := runTest([]string{"convert", "-f", "-", "-format", "json", "-to", "yaml"}, jsonOutput)
+	if exitCode != 0 {
+		t.Fatalf("%d: %s", exitCode, stderr)
+	}
+	jsonOutput, stderr, exitCode = runTest([]string{"convert", "-f", "-"}, yamlOutput)
+	if exitCode != 0 {
+		t.Fatalf("%d: %s", exitCode, stderr)
+	}
+	checkTestExternalCheckoutOptions(t, expected, jsonOutput)
+
+	v2Output, stderr, exitCode := runTest([]string{"convert", "-f", "-", "-api-version", "v2"}, jsonOutput)
+	if exitCode != 0 {
+		t.Fatalf("%d: %s", exitCode, stderr)
+	}
+	if !strings.Contains(v2Output, `"apiVersion": "v2"`) {
+		t.Errorf("expected v2 output, got %s", v2Output)
+	}
+	jsonOutput, stderr, exitCode = runTest([]string{"convert", "-f", "-"}, v2Output)
+	if exitCode != 0 {
+		t.Fatalf("%d: %s", exitCode, stderr)
+	}
+	checkTestExternalCheckoutOptions(t, expected, jsonOutput)
+
+	flagsOutput, stderr, exitCode := runTest([]string{"convert", "-f", "-", "-to", "flags"}, jsonOutput)
+	if exitCode != 0 {
+		t.Fatalf("%d: %s", exitCode, stderr)
+	}
+	if !strings.Contains(stderr, "-access-token-file") {
+		t.Errorf("expected a warning about -access-token-file, got %q", stderr)
+	}
+	args := append([]string{"convert"}, strings.Fields(flagsOutput)...)
+	jsonOutput, stderr, exitCode = runTest(append(args, "-access-token-file", "-"), "token")
+	if exitCode != 0 {
+		t.Fatalf("%v: %d: %s", args, exitCode, stderr)
+	}
+	checkTestExternalCheckoutOptions(t, expected, jsonOutput)
+
+	_, stderr, exitCode = runTest([]string{"convert", "-f", "-", "-access-token-file", "-", "-security-type", "accessToken"}, testGithubJSON)
+	if exitCode != exitCodeUsage || !strings.Contains(stderr, "stdin") {
+		t.Errorf("expected stdin to only be read once, got %d: %s", exitCode, stderr)
+	}
+}
+
+func TestURL(t *testing.T) {
+	t.Parallel()
+	stdout, stderr, exitCode := runTest([]string{"url", "-f", "-"}, testGithubJSON)
+	if exitCode != 0 {
+		t.Fatalf("%d: %s", exitCode, stderr)
+	}
+	checkoutRemoteURLs := &scm.CheckoutRemoteURLs{}
+	if err := json.Unmarshal([]byte(stdout), checkoutRemoteURLs); err != nil {
+		t.Fatal(err)
+	}
+	if checkoutRemoteURLs.WebURL != "https://github.com/peter-edge/smartystreets_ruby" {
+		t.Errorf("unexpected web URL %s", checkoutRemoteURLs.WebURL)
+	}
+}
+
+func TestCheckout(t *testing.T) {
+	t.Parallel()
+	dirPath := newTestDir(t)
+	defer os.RemoveAll(dirPath)
+	repositoryPath := filepath.Join(dirPath, "repository")
+	for _, args := range [][]string{
+		{"init", "-q", repositoryPath},
+		{"-C", repositoryPath, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "initial"},
+		{"-C", repositoryPath, "branch", "-M", "master"},
+	} {
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("%v: %v %s", args, err, string(output))
+		}
+	}
+	output, err := exec.Command("git", "-C", repositoryPath, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitID := strings.TrimSpace(string(output))
+	stdout, stderr, exitCode := runTest(
+		[]string{"checkout", "-type", "local", "-path", repositoryPath, "-branch", "master", "-commit-id", commitID, filepath.Join(dirPath, "checkout")},
+		"",
+	)
+	if exitCode != 0 {
+		t.Fatalf("%d: %s", exitCode, stderr)
+	}
+	checkoutResult := &scm.CheckoutResult{}
+	if err := json.Unmarshal([]byte(stdout), checkoutResult); err != nil {
+		t.Fatal(err)
+	}
+	if checkoutResult.Revision != commitID {
+		t.Errorf("expected revision %s, got %s", commitID, checkoutResult.Revision)
+	}
+	_, _, exitCode = runTest([]string{"checkout", "-type", "local", "-path", repositoryPath, "-branch", "master", "-commit-id", commitID}, "")
+	if exitCode != exitCodeUsage {
+		t.Errorf("expected exit code %d without a path, got %d", exitCodeUsage, exitCode)
+	}
+}
+
+func TestNewServer(t *testing.T) {
+	t.Parallel()
+	server := newServer(":8080", http.NotFoundHandler())
+	if server.ReadHeaderTimeout == 0 || server.ReadTimeout == 0 || server.IdleTimeout == 0 {
+		t.Errorf("expected read and idle timeouts, got %+v", server)
+	}
+	if server.WriteTimeout != 0 {
+		t.Errorf("expected no write timeout, got %v", server.WriteTimeout)
+	}
+}
+
+func TestGetExitCode(t *testing.T) {
+	t.Parallel()
+	for _, testCase := range []struct {
+		err              error
+		expectedExitCode int
+	}{
+		{newUsageError("usage"), exitCodeUsage},
+		{scm.ValidationErrors{}, exitCodeValidation},
+		{errors.New("CouldNotClone: exit status 128 git@github.com: Permission denied (publickey)."), exitCodeAuth},
+		{errors.New("CouldNotClone: exit status 128 fatal: could not read Username for 'https://github.com': terminal prompts disabled"), exitCodeAuth},
+		{errors.New("CouldNotClone: exit status 128 fatal: unable to look up github.com (port 9418) (Name or service not known)"), exitCodeNetwork},
+		{errors.New("CouldNotClone: exit status 128 fatal: unable to access 'https://github.com/': Could not resolve host: github.com"), exitCodeNetwork},
+		{errors.New("CouldNotClone: exit status 128 fatal: reference is not a tree: a40e854"), exitCodeError},
+		{errors.New("CouldNotCheckout: exit status 1 svn: E170001: Authentication required for '<https://svn.example.com:443> Subversion'"), exitCodeAuth},
+		{errors.New("open /srv/checkout/.git/config: permission denied"), exitCodeError},
+		{errors.New("CouldNotMakeDirectory: exit status 1 mkdir: cannot create directory '/srv/checkout': Permission denied"), exitCodeError},
+	} {
+		if exitCode := getExitCode(testCase.err); exitCode != testCase.expectedExitCode {
+			t.Errorf("%v: expected exit code %d, got %d", testCase.err, testCase.expectedExitCode, exitCode)
+		}
+	}
+}
+
+func runTest(args []string, stdin string) (string, string, int) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), stderr.String(), exitCode
+}
+
+func checkTestExternalCheckoutOptions(t *testing.T, expected *scm.ExternalCheckoutOptions, output string) {
+	expectedData, err := json.MarshalIndent(expected, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(output) != string(expectedData) {
+		t.Errorf("expected %s, got %s", string(expectedData), output)
+	}
+}
+
+func newTestDir(t *testing.T) string {
+	dirPath, err := ioutil.TempDir("", "go-scm-cmd-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dirPath
+}
+
+func writeTestFile(t *testing.T, dirPath string, name string, content string) string {
+	filePath := filepath.Join(dirPath, name)
+	if err := ioutil.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return filePath
+}
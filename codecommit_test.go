@@ -0,0 +1,183 @@
+package scm
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	testAWSAccessKeyID     = "AKIDEXAMPLE"
+	testAWSSecretAccessKey = "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"
+)
+
+var (
+	testCodeCommitTime = time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC)
+)
+
+func TestCodeCommitSigV4Password(t *testing.T) {
+	t.Parallel()
+	password := getCodeCommitSigV4Password(
+		testAWSSecretAccessKey,
+		"us-east-1",
+		"git-codecommit.us-east-1.amazonaws.com",
+		"/v1/repos/banana",
+		testCodeCommitTime,
+	)
+	expected := "20150830T123600Zbcd064761cb62848f802d5f7c9e193547b4021df57bf9650016d91eb6a289bf1"
+	if password != expected {
+		t.Errorf("expected %s, got %s", expected, password)
+	}
+	// the signature is always computed over UTC
+	password = getCodeCommitSigV4Password(
+		testAWSSecretAccessKey,
+		"us-east-1",
+		"git-codecommit.us-east-1.amazonaws.com",
+		"/v1/repos/banana",
+		testCodeCommitTime.In(time.FixedZone("UTC-7", -7*60*60)),
+	)
+	if password != expected {
+		t.Errorf("expected %s, got %s", expected, password)
+	}
+}
+
+func TestCodeCommitURL(t *testing.T) {
+	t.Parallel()
+	codeCommitCheckoutOptions := &CodeCommitCheckoutOptions{
+		Region:     "us-east-1",
+		Repository: "banana",
+		SSHKeyID:   "APKAEIBAERJR2EXAMPLE",
+	}
+	for _, tc := range []struct {
+		securityOptions SecurityOptions
+		expected        string
+	}{
+		{nil, "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/banana"},
+		{&SSHSecurityOptions{}, "ssh://APKAEIBAERJR2EXAMPLE@git-codecommit.us-east-1.amazonaws.com/v1/repos/banana"},
+		{
+			&AWSAccessKeySecurityOptions{
+				AccessKeyID:     testAWSAccessKeyID,
+				SecretAccessKey: testAWSSecretAccessKey,
+			},
+			"https://AKIDEXAMPLE:20150830T123600Zbcd064761cb62848f802d5f7c9e193547b4021df57bf9650016d91eb6a289bf1@git-codecommit.us-east-1.amazonaws.com/v1/repos/banana",
+		},
+		{
+			&AWSAccessKeySecurityOptions{
+				AccessKeyID:     testAWSAccessKeyID,
+				SecretAccessKey: testAWSSecretAccessKey,
+				SessionToken:    "to/ken",
+			},
+			"https://AKIDEXAMPLE%25to%2Fken:20150830T123600Zbcd064761cb62848f802d5f7c9e193547b4021df57bf9650016d91eb6a289bf1@git-codecommit.us-east-1.amazonaws.com/v1/repos/banana",
+		},
+	} {
+		codeCommitCheckoutOptions.SecurityOptions = tc.securityOptions
+		url, err := getCodeCommitURL(codeCommitCheckoutOptions, testCodeCommitTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if url != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, url)
+		}
+	}
+}
+
+func TestCodeCommitValidation(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		codeCommitCheckoutOptions *CodeCommitCheckoutOptions
+		expected                  ValidationErrorType
+	}{
+		{
+			&CodeCommitCheckoutOptions{
+				Region:          "us-east-1",
+				Repository:      "banana",
+				Branch:          "master",
+				CommitID:        testBananaCommitID,
+				SecurityOptions: &SSHSecurityOptions{},
+			},
+			ValidationErrorTypeRequiredFieldMissing,
+		},
+		{
+			&CodeCommitCheckoutOptions{
+				Region:     "us-east-1",
+				Repository: "banana",
+				SSHKeyID:   "APKAEIBAERJR2EXAMPLE",
+				Branch:     "master",
+				CommitID:   testBananaCommitID,
+				SecurityOptions: &AWSAccessKeySecurityOptions{
+					AccessKeyID:     testAWSAccessKeyID,
+					SecretAccessKey: testAWSSecretAccessKey,
+				},
+			},
+			ValidationErrorTypeFieldShouldNotBeSet,
+		},
+		{
+			&CodeCommitCheckoutOptions{
+				Region:     "us-east-1",
+				Repository: "banana",
+				Branch:     "master",
+				CommitID:   testBananaCommitID,
+				SecurityOptions: &AWSAccessKeySecurityOptions{
+					AccessKeyID: testAWSAccessKeyID,
+				},
+			},
+			ValidationErrorTypeRequiredFieldMissing,
+		},
+		{
+			&CodeCommitCheckoutOptions{
+				Region:     "us-east-1",
+				Repository: "banana",
+				Branch:     "master",
+				CommitID:   testBananaCommitID,
+				SecurityOptions: &AccessTokenSecurityOptions{
+					AccessToken: "token",
+				},
+			},
+			ValidationErrorTypeSecurityNotImplementedForCheckoutOptionsType,
+		},
+	} {
+		err := Validate(tc.codeCommitCheckoutOptions)
+		validationErrors, ok := err.(ValidationErrors)
+		if !ok || len(validationErrors) != 1 {
+			t.Errorf("expected one ValidationError, got %v", err)
+			continue
+		}
+		validationError := validationErrors[0]
+		if validationError.Type() != tc.expected {
+			t.Errorf("expected %v, got %v", tc.expected, validationError.Type())
+		}
+	}
+}
+
+func TestCodeCommitConvertExternalCheckoutOptions(t *testing.T) {
+	t.Parallel()
+	checkoutOptions, err := ConvertExternalCheckoutOptions(
+		&ExternalCheckoutOptions{
+			Type:       "codeCommit",
+			Region:     "us-east-1",
+			Repository: "banana",
+			Branch:     "master",
+			CommitID:   testBananaCommitID,
+			SecurityOptions: &ExternalSecurityOptions{
+				Type:            "awsAccessKey",
+				AccessKeyID:     testAWSAccessKeyID,
+				SecretAccessKey: testAWSSecretAccessKey,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validateCheckoutOptions(checkoutOptions); err != nil {
+		t.Fatal(err)
+	}
+	externalCheckoutOptions, err := ConvertCheckoutOptions(checkoutOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if externalCheckoutOptions.Region != "us-east-1" {
+		t.Errorf("expected us-east-1, got %s", externalCheckoutOptions.Region)
+	}
+	if externalCheckoutOptions.SecurityOptions.SecretAccessKey != testAWSSecretAccessKey {
+		t.Errorf("expected %s, got %s", testAWSSecretAccessKey, externalCheckoutOptions.SecurityOptions.SecretAccessKey)
+	}
+}
@@ -0,0 +1,136 @@
+package scm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/codeship/go-exec"
+)
+
+const (
+	treeDigestPrefix = "sha256:"
+)
+
+type TreeDigestOptions struct {
+	// Paths are slash-separated globs, as matched by path.Match, relative to the checkout.
+	// If set, only files that match a glob, or are under a directory that matches a glob, are hashed.
+	Paths []string
+}
+
+// TreeDigest returns a digest of the files under the checkout at absolutePath, in the form
+// sha256:<hex>, that only changes if the content, permissions or paths of the files change.
+//
+// VCS metadata is ignored as in Export, and the commit of each git submodule is hashed
+// along with its files. treeDigestOptions can be nil.
+func TreeDigest(absolutePath string, treeDigestOptions *TreeDigestOptions) (string, error) {
+	return getTreeDigest(absolutePath, treeDigestOptions)
+}
+
+// ***** PRIVATE *****
+
+func getTreeDigest(absolutePath string, treeDigestOptions *TreeDigestOptions) (string, error) {
+	if treeDigestOptions == nil {
+		treeDigestOptions = &TreeDigestOptions{}
+	}
+	for _, pattern := range treeDigestOptions.Paths {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return "", fmt.Errorf("scm: InvalidTreeDigestPath: %v", pattern)
+		}
+	}
+	exportEntries, err := getExportEntries(absolutePath, false)
+	if err != nil {
+		return "", err
+	}
+	executor, err := exec.NewOsExecutor(absolutePath)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	for _, exportEntry := range exportEntries {
+		name := strings.TrimSuffix(exportEntry.name, "/")
+		if !isTreeDigestPathIncluded(name, treeDigestOptions.Paths) {
+			continue
+		}
+		switch {
+		case exportEntry.mode.IsDir():
+			if _, err := os.Lstat(filepath.Join(exportEntry.path, ".git")); err != nil {
+				continue
+			}
+			commitID, err := getSubmoduleCommitID(executor, name)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(hash, "submodule %s %s\x00", commitID, name)
+		case exportEntry.mode&os.ModeSymlink != 0:
+			fmt.Fprintf(hash, "symlink %s %s\x00", getSHA256([]byte(exportEntry.link)), name)
+		default:
+			contentHash, err := getFileSHA256(exportEntry.path)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(hash, "file %04o %s %s\x00", exportEntry.mode.Perm(), contentHash, name)
+		}
+	}
+	return treeDigestPrefix + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// isTreeDigestPathIncluded returns true if there are no patterns, or if a pattern matches name or one of its parent directories.
+func isTreeDigestPathIncluded(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for dir := name; dir != "."; dir = path.Dir(dir) {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, dir); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getSubmoduleCommitID(executor exec.Executor, name string) (string, error) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"git", "rev-parse", "HEAD"},
+			SubDir: filepath.FromSlash(name),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return "", fmt.Errorf("CouldNotGetRevision: %v %v", err.Error(), stderr.String())
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func getFileSHA256(filePath string) (retValue string, retErr error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func getSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,100 @@
+package scm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTreeDigest(t *testing.T) {
+	// not parallel since submodules from local paths need protocol.file.allow in the environment
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+	checkoutOptions, _ := newTestExportRepository(t)
+	first := getTempDir(t)
+	if _, err := Checkout(checkoutOptions, filepath.Join(first, "checkout")); err != nil {
+		t.Fatal(err)
+	}
+	first = filepath.Join(first, "checkout")
+	second := getTempDir(t)
+	if _, err := Checkout(checkoutOptions, filepath.Join(second, "checkout")); err != nil {
+		t.Fatal(err)
+	}
+	second = filepath.Join(second, "checkout")
+
+	digest := getTestTreeDigest(t, first, nil)
+	if !strings.HasPrefix(digest, "sha256:") || len(digest) != len("sha256:")+64 {
+		t.Errorf("unexpected digest %s", digest)
+	}
+	binDigest := getTestTreeDigest(t, first, []string{"bin"})
+	subDigest := getTestTreeDigest(t, first, []string{"sub"})
+	for _, paths := range [][]string{nil, {"bin"}, {"sub"}} {
+		if getTestTreeDigest(t, first, paths) != getTestTreeDigest(t, second, paths) {
+			t.Errorf("%v: expected identical checkouts to have the same digest", paths)
+		}
+	}
+	if binDigest == digest || subDigest == digest || binDigest == subDigest {
+		t.Error("expected digests restricted to paths to differ")
+	}
+	if getTestTreeDigest(t, first, []string{"bin/*.sh"}) != binDigest {
+		t.Error("expected bin/*.sh to match all of bin")
+	}
+
+	// VCS metadata is ignored
+	runTestCommand(t, second, "git", "config", "user.name", "banana")
+	if getTestTreeDigest(t, second, nil) != digest {
+		t.Error("expected changes to .git to be ignored")
+	}
+	// the submodule commit is included
+	runTestCommand(t, filepath.Join(second, "sub"), "git", "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "empty")
+	if getTestTreeDigest(t, second, []string{"sub"}) == subDigest {
+		t.Error("expected the submodule commit to change the digest")
+	}
+	if getTestTreeDigest(t, second, []string{"bin"}) != binDigest {
+		t.Error("expected the submodule commit to not change the digest of bin")
+	}
+	// permissions and content are included
+	if err := os.Chmod(filepath.Join(second, "bin", "run.sh"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if getTestTreeDigest(t, second, []string{"bin"}) == binDigest {
+		t.Error("expected the permissions to change the digest")
+	}
+	if err := ioutil.WriteFile(filepath.Join(first, "README.md"), []byte("apple\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if getTestTreeDigest(t, first, nil) == digest {
+		t.Error("expected the content to change the digest")
+	}
+	if getTestTreeDigest(t, first, []string{"bin"}) != binDigest {
+		t.Error("expected README.md to not change the digest of bin")
+	}
+}
+
+func TestTreeDigestFossil(t *testing.T) {
+	t.Parallel()
+	digest := getTestTreeDigest(t, newTestFossilCheckoutDir(t, "first clone"), nil)
+	if getTestTreeDigest(t, newTestFossilCheckoutDir(t, "second clone"), nil) != digest {
+		t.Error("expected the Fossil repository and checkout databases to be ignored")
+	}
+}
+
+func TestTreeDigestInvalidPath(t *testing.T) {
+	t.Parallel()
+	for _, pattern := range []string{"", "[", "bin/[a-"} {
+		if _, err := TreeDigest(getTempDir(t), &TreeDigestOptions{Paths: []string{pattern}}); err == nil || !strings.Contains(err.Error(), "InvalidTreeDigestPath") {
+			t.Errorf("%q: expected InvalidTreeDigestPath, got %v", pattern, err)
+		}
+	}
+}
+
+func getTestTreeDigest(t *testing.T, absolutePath string, paths []string) string {
+	digest, err := TreeDigest(absolutePath, &TreeDigestOptions{Paths: paths})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return digest
+}
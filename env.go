@@ -0,0 +1,319 @@
+package scm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	EnvType                     = "TYPE"
+	EnvRepoURL                  = "REPO_URL"
+	EnvUser                     = "USER"
+	EnvHost                     = "HOST"
+	EnvPath                     = "PATH"
+	EnvOrganization             = "ORGANIZATION"
+	EnvProject                  = "PROJECT"
+	EnvRegion                   = "REGION"
+	EnvSSHKeyID                 = "SSH_KEY_ID"
+	EnvUsername                 = "USERNAME"
+	EnvPasswordFile             = "PASSWORD_FILE"
+	EnvRepository               = "REPOSITORY"
+	EnvBranch                   = "BRANCH"
+	EnvRef                      = "REF"
+	EnvCommitSHA                = "COMMIT_SHA"
+	EnvCommitMessage            = "COMMIT_MESSAGE"
+	EnvCommitterName            = "COMMITTER_NAME"
+	EnvCommitterUsername        = "COMMITTER_USERNAME"
+	EnvCommitterEmail           = "COMMITTER_EMAIL"
+	EnvSparsePaths              = "SPARSE_PATHS"
+	EnvCloneFilter              = "CLONE_FILTER"
+	EnvSubmodules               = "SUBMODULES"
+	EnvSubmodulesShallow        = "SUBMODULES_SHALLOW"
+	EnvSubmodulesDepth          = "SUBMODULES_DEPTH"
+	EnvSubmodulesJobs           = "SUBMODULES_JOBS"
+	EnvLFS                      = "LFS"
+	EnvLFSExclude               = "LFS_EXCLUDE"
+	EnvSSHPrivateKeyFile        = "SSH_PRIVATE_KEY_FILE"
+	EnvSSHStrictHostKeyChecking = "SSH_STRICT_HOST_KEY_CHECKING"
+	EnvAccessTokenFile          = "ACCESS_TOKEN_FILE"
+	EnvAWSAccessKeyID           = "AWS_ACCESS_KEY_ID"
+	EnvAWSSecretAccessKeyFile   = "AWS_SECRET_ACCESS_KEY_FILE"
+	EnvAWSSessionTokenFile      = "AWS_SESSION_TOKEN_FILE"
+)
+
+// CheckoutOptionsFromEnv reads CheckoutOptions from the environment variables
+// prefix + "_" + one of the Env constants, for example CI_REPO_URL for the prefix CI.
+// The prefix cannot be empty.
+// See ExternalCheckoutOptionsFromEnv for how the variables are mapped.
+func CheckoutOptionsFromEnv(prefix string) (CheckoutOptions, error) {
+	externalCheckoutOptions, err := getExternalCheckoutOptionsFromEnv(prefix, os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+	return convertExternalCheckoutOptions(externalCheckoutOptions)
+}
+
+// ExternalCheckoutOptionsFromEnv reads ExternalCheckoutOptions from the environment variables
+// prefix + "_" + one of the Env constants.
+//
+// REPO_URL is parsed with ParseRemoteURL, or used as the URL for svn and fossil. TYPE is only required
+// if REPO_URL is not set or would be parsed as a different type: with TYPE set to gitea, git or hg,
+// REPO_URL is parsed as a URL for that type on any host, such as https://gitea.example.com/team/repo.git
+// for gitea or git@github.com:team/repo.git for git, and any other TYPE must match the type of REPO_URL.
+// Any other variable that is set overrides the value from REPO_URL. COMMIT_SHA is the changeset ID for
+// hg types, the revision for svn, the check-in ID for fossil, and the commit ID otherwise. SPARSE_PATHS
+// is a comma-separated list. SUBMODULES is none, all, or a comma-separated list of submodule paths.
+//
+// Secrets are read from the files named by the *_FILE variables, with surrounding whitespace removed
+// except for SSH private keys. SSH_*, ACCESS_TOKEN_FILE and AWS_* each select a SecurityOptions type,
+// so only one of them may be used.
+func ExternalCheckoutOptionsFromEnv(prefix string) (*ExternalCheckoutOptions, error) {
+	return getExternalCheckoutOptionsFromEnv(prefix, os.Getenv)
+}
+
+// ***** PRIVATE *****
+
+func getExternalCheckoutOptionsFromEnv(prefix string, getenv func(string) string) (*ExternalCheckoutOptions, error) {
+	// without a prefix, PATH, USER and HOST would be read from the process environment
+	if prefix == "" {
+		return nil, fmt.Errorf("scm: EnvPrefixNotSet")
+	}
+	env := func(name string) string {
+		return getenv(getEnvName(prefix, name))
+	}
+	checkoutOptionsType := env(EnvType)
+	repoURL := env(EnvRepoURL)
+	if checkoutOptionsType == "" && repoURL == "" {
+		return nil, newErrorEnvNotSet(getEnvName(prefix, EnvType), getEnvName(prefix, EnvRepoURL))
+	}
+	externalCheckoutOptions := &ExternalCheckoutOptions{
+		Type: checkoutOptionsType,
+	}
+	if repoURL != "" {
+		switch checkoutOptionsType {
+		case CheckoutOptionsTypeSvn.String(), CheckoutOptionsTypeFossil.String():
+			externalCheckoutOptions.URL = repoURL
+		default:
+			checkoutOptions, err := parseRemoteURLForType(repoURL, checkoutOptionsType)
+			if err != nil {
+				return nil, err
+			}
+			parsedExternalCheckoutOptions, err := convertCheckoutOptions(checkoutOptions)
+			if err != nil {
+				return nil, err
+			}
+			if checkoutOptionsType != "" && checkoutOptionsType != parsedExternalCheckoutOptions.Type {
+				return nil, newErrorEnvTypeMismatch(getEnvName(prefix, EnvRepoURL), parsedExternalCheckoutOptions.Type, checkoutOptionsType)
+			}
+			externalCheckoutOptions = parsedExternalCheckoutOptions
+		}
+	}
+	for name, field := range map[string]*string{
+		EnvUser:              &externalCheckoutOptions.User,
+		EnvHost:              &externalCheckoutOptions.Host,
+		EnvPath:              &externalCheckoutOptions.Path,
+		EnvOrganization:      &externalCheckoutOptions.Organization,
+		EnvProject:           &externalCheckoutOptions.Project,
+		EnvRegion:            &externalCheckoutOptions.Region,
+		EnvSSHKeyID:          &externalCheckoutOptions.SSHKeyID,
+		EnvUsername:          &externalCheckoutOptions.Username,
+		EnvRepository:        &externalCheckoutOptions.Repository,
+		EnvBranch:            &externalCheckoutOptions.Branch,
+		EnvRef:               &externalCheckoutOptions.Ref,
+		EnvCommitMessage:     &externalCheckoutOptions.CommitMessage,
+		EnvCommitterName:     &externalCheckoutOptions.CommitterName,
+		EnvCommitterUsername: &externalCheckoutOptions.CommitterUsername,
+		EnvCommitterEmail:    &externalCheckoutOptions.Email,
+		EnvCloneFilter:       &externalCheckoutOptions.CloneFilter,
+	} {
+		if value := env(name); value != "" {
+			*field = value
+		}
+	}
+	if commitSHA := env(EnvCommitSHA); commitSHA != "" {
+		switch externalCheckoutOptions.Type {
+		case CheckoutOptionsTypeHg.String(), CheckoutOptionsTypeBitbucketHg.String():
+			externalCheckoutOptions.ChangesetID = commitSHA
+		case CheckoutOptionsTypeSvn.String():
+			externalCheckoutOptions.Revision = commitSHA
+		case CheckoutOptionsTypeFossil.String():
+			externalCheckoutOptions.CheckinID = commitSHA
+		default:
+			externalCheckoutOptions.CommitID = commitSHA
+		}
+	}
+	if sparsePaths := env(EnvSparsePaths); sparsePaths != "" {
+		externalCheckoutOptions.SparsePaths = strings.Split(sparsePaths, ",")
+	}
+	externalSubmoduleOptions, err := getExternalSubmoduleOptionsFromEnv(prefix, env)
+	if err != nil {
+		return nil, err
+	}
+	if externalSubmoduleOptions != nil {
+		externalCheckoutOptions.Submodules = externalSubmoduleOptions
+	}
+	externalCheckoutOptions.LFS = getExternalLFSOptionsFromEnv(env)
+	password, err := readEnvFile(prefix, env, EnvPasswordFile, true)
+	if err != nil {
+		return nil, err
+	}
+	if password != "" {
+		externalCheckoutOptions.Password = password
+	}
+	externalSecurityOptions, err := getExternalSecurityOptionsFromEnv(prefix, env)
+	if err != nil {
+		return nil, err
+	}
+	if externalSecurityOptions != nil {
+		externalCheckoutOptions.SecurityOptions = externalSecurityOptions
+	}
+	return externalCheckoutOptions, nil
+}
+
+func getExternalSecurityOptionsFromEnv(prefix string, env func(string) string) (*ExternalSecurityOptions, error) {
+	var externalSecurityOptions []*ExternalSecurityOptions
+	var names []string
+	if env(EnvSSHPrivateKeyFile) != "" || env(EnvSSHStrictHostKeyChecking) != "" {
+		privateKey, err := readEnvFile(prefix, env, EnvSSHPrivateKeyFile, false)
+		if err != nil {
+			return nil, err
+		}
+		var strictHostKeyChecking bool
+		if value := env(EnvSSHStrictHostKeyChecking); value != "" {
+			strictHostKeyChecking, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, newErrorInvalidEnv(getEnvName(prefix, EnvSSHStrictHostKeyChecking), value)
+			}
+		}
+		externalSecurityOptions = append(externalSecurityOptions, &ExternalSecurityOptions{
+			Type:                  "ssh",
+			StrictHostKeyChecking: strictHostKeyChecking,
+			PrivateKey:            privateKey,
+		})
+		names = append(names, getEnvName(prefix, "SSH_*"))
+	}
+	if env(EnvAccessTokenFile) != "" {
+		accessToken, err := readEnvFile(prefix, env, EnvAccessTokenFile, true)
+		if err != nil {
+			return nil, err
+		}
+		externalSecurityOptions = append(externalSecurityOptions, &ExternalSecurityOptions{
+			Type:        "accessToken",
+			AccessToken: accessToken,
+		})
+		names = append(names, getEnvName(prefix, EnvAccessTokenFile))
+	}
+	if env(EnvAWSAccessKeyID) != "" || env(EnvAWSSecretAccessKeyFile) != "" || env(EnvAWSSessionTokenFile) != "" {
+		secretAccessKey, err := readEnvFile(prefix, env, EnvAWSSecretAccessKeyFile, true)
+		if err != nil {
+			return nil, err
+		}
+		sessionToken, err := readEnvFile(prefix, env, EnvAWSSessionTokenFile, true)
+		if err != nil {
+			return nil, err
+		}
+		externalSecurityOptions = append(externalSecurityOptions, &ExternalSecurityOptions{
+			Type:            "awsAccessKey",
+			AccessKeyID:     env(EnvAWSAccessKeyID),
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    sessionToken,
+		})
+		names = append(names, getEnvName(prefix, "AWS_*"))
+	}
+	switch len(externalSecurityOptions) {
+	case 0:
+		return nil, nil
+	case 1:
+		return externalSecurityOptions[0], nil
+	default:
+		return nil, newErrorEnvConflict(names...)
+	}
+}
+
+func getExternalSubmoduleOptionsFromEnv(prefix string, env func(string) string) (*ExternalSubmoduleOptions, error) {
+	if env(EnvSubmodules) == "" && env(EnvSubmodulesShallow) == "" && env(EnvSubmodulesDepth) == "" && env(EnvSubmodulesJobs) == "" {
+		return nil, nil
+	}
+	externalSubmoduleOptions := &ExternalSubmoduleOptions{}
+	switch submodules := env(EnvSubmodules); submodules {
+	case "", "all":
+	case "none":
+		externalSubmoduleOptions.Disabled = true
+	default:
+		externalSubmoduleOptions.Paths = strings.Split(submodules, ",")
+	}
+	if value := env(EnvSubmodulesShallow); value != "" {
+		shallow, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, newErrorInvalidEnv(getEnvName(prefix, EnvSubmodulesShallow), value)
+		}
+		externalSubmoduleOptions.Shallow = shallow
+	}
+	for name, field := range map[string]*int{
+		EnvSubmodulesDepth: &externalSubmoduleOptions.Depth,
+		EnvSubmodulesJobs:  &externalSubmoduleOptions.Jobs,
+	} {
+		if value := env(name); value != "" {
+			i, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, newErrorInvalidEnv(getEnvName(prefix, name), value)
+			}
+			*field = i
+		}
+	}
+	return externalSubmoduleOptions, nil
+}
+
+// getExternalLFSOptionsFromEnv reads EnvLFS as all or the Include patterns separated by commas,
+// and EnvLFSExclude as the Exclude patterns separated by commas.
+func getExternalLFSOptionsFromEnv(env func(string) string) *ExternalLFSOptions {
+	if env(EnvLFS) == "" && env(EnvLFSExclude) == "" {
+		return nil
+	}
+	externalLFSOptions := &ExternalLFSOptions{}
+	if lfs := env(EnvLFS); lfs != "" && lfs != "all" {
+		externalLFSOptions.Include = strings.Split(lfs, ",")
+	}
+	if exclude := env(EnvLFSExclude); exclude != "" {
+		externalLFSOptions.Exclude = strings.Split(exclude, ",")
+	}
+	return externalLFSOptions
+}
+
+func readEnvFile(prefix string, env func(string) string, name string, trimSpace bool) (string, error) {
+	filePath := env(name)
+	if filePath == "" {
+		return "", nil
+	}
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("scm: CouldNotReadEnvFile: %v: %v", getEnvName(prefix, name), err)
+	}
+	if trimSpace {
+		return strings.TrimSpace(string(data)), nil
+	}
+	return string(data), nil
+}
+
+func getEnvName(prefix string, name string) string {
+	return prefix + "_" + name
+}
+
+func newErrorEnvNotSet(names ...string) error {
+	return fmt.Errorf("scm: EnvNotSet: one of %v must be set", strings.Join(names, ", "))
+}
+
+func newErrorInvalidEnv(name string, value string) error {
+	return fmt.Errorf("scm: InvalidEnv: %v=%v", name, value)
+}
+
+func newErrorEnvTypeMismatch(name string, parsedType string, checkoutOptionsType string) error {
+	return fmt.Errorf("scm: EnvTypeMismatch: %v is a %v URL but the type is %v", name, parsedType, checkoutOptionsType)
+}
+
+func newErrorEnvConflict(names ...string) error {
+	return fmt.Errorf("scm: EnvConflict: only one of %v can be set", strings.Join(names, ", "))
+}
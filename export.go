@@ -0,0 +1,277 @@
+package scm
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatTar   ExportFormat = "tar"
+	ExportFormatTarGz ExportFormat = "tar.gz"
+	ExportFormatZip   ExportFormat = "zip"
+)
+
+var (
+	// the earliest time a zip file can hold
+	exportModTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	vcsMetadataNames = map[string]bool{
+		".git":               true,
+		".hg":                true,
+		".svn":               true,
+		".fslckout":          true,
+		"_FOSSIL_":           true,
+		fossilRepositoryFile: true,
+	}
+)
+
+type ExportOptions struct {
+	// IncludeVCSMetadata includes .git, .hg, .svn and the fossil checkout and repository databases in the archive.
+	// Their contents differ between checkouts, so an archive that includes them is not reproducible.
+	IncludeVCSMetadata bool
+}
+
+// ExportFormatOf returns the ExportFormat for tar, tar.gz or zip.
+func ExportFormatOf(s string) (ExportFormat, error) {
+	switch exportFormat := ExportFormat(s); exportFormat {
+	case ExportFormatTar, ExportFormatTarGz, ExportFormatZip:
+		return exportFormat, nil
+	default:
+		return "", fmt.Errorf("scm: UnknownExportFormat: %v", s)
+	}
+}
+
+// Export checks out checkoutOptions into a temporary directory and writes the checked out tree,
+// including submodules, to writer as an archive in exportFormat. exportOptions can be nil.
+//
+// Entries are written in lexical order with normalized modification times, ownership and
+// permissions, so checkouts of the same commit produce byte-identical archives.
+func Export(
+	checkoutOptions CheckoutOptions,
+	writer io.Writer,
+	exportFormat ExportFormat,
+	exportOptions *ExportOptions,
+) (*CheckoutResult, error) {
+	return export(
+		checkoutOptions,
+		writer,
+		exportFormat,
+		exportOptions,
+	)
+}
+
+// ***** PRIVATE *****
+
+type exportEntry struct {
+	path string
+	// name is the slash-separated path relative to the exported directory, with a trailing slash for directories
+	name string
+	mode os.FileMode
+	link string
+}
+
+func export(
+	checkoutOptions CheckoutOptions,
+	writer io.Writer,
+	exportFormat ExportFormat,
+	exportOptions *ExportOptions,
+) (retCheckoutResult *CheckoutResult, retErr error) {
+	if _, err := ExportFormatOf(string(exportFormat)); err != nil {
+		return nil, err
+	}
+	if exportOptions == nil {
+		exportOptions = &ExportOptions{}
+	}
+	tempDir, err := ioutil.TempDir("", "go-scm-export")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	path := filepath.Join(tempDir, "checkout")
+	checkoutResult, err := checkout(checkoutOptions, path)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeExport(path, writer, exportFormat, exportOptions.IncludeVCSMetadata); err != nil {
+		return nil, err
+	}
+	return checkoutResult, nil
+}
+
+func writeExport(dirPath string, writer io.Writer, exportFormat ExportFormat, includeVCSMetadata bool) error {
+	exportEntries, err := getExportEntries(dirPath, includeVCSMetadata)
+	if err != nil {
+		return err
+	}
+	switch exportFormat {
+	case ExportFormatTar:
+		return writeExportTar(exportEntries, writer)
+	case ExportFormatTarGz:
+		gzipWriter := gzip.NewWriter(writer)
+		if err := writeExportTar(exportEntries, gzipWriter); err != nil {
+			return err
+		}
+		return gzipWriter.Close()
+	case ExportFormatZip:
+		return writeExportZip(exportEntries, writer)
+	default:
+		_, err := ExportFormatOf(string(exportFormat))
+		return err
+	}
+}
+
+// filepath.Walk visits files in lexical order, which makes the order of the entries stable.
+func getExportEntries(dirPath string, includeVCSMetadata bool) ([]*exportEntry, error) {
+	var exportEntries []*exportEntry
+	if err := filepath.Walk(
+		dirPath,
+		func(path string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if path == dirPath {
+				return nil
+			}
+			if !includeVCSMetadata && vcsMetadataNames[fileInfo.Name()] {
+				if fileInfo.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			relPath, err := filepath.Rel(dirPath, path)
+			if err != nil {
+				return err
+			}
+			exportEntry := &exportEntry{
+				path: path,
+				name: filepath.ToSlash(relPath),
+			}
+			switch {
+			case fileInfo.IsDir():
+				exportEntry.name += "/"
+				exportEntry.mode = os.ModeDir | 0755
+			case fileInfo.Mode()&os.ModeSymlink != 0:
+				exportEntry.mode = os.ModeSymlink | 0777
+				if exportEntry.link, err = os.Readlink(path); err != nil {
+					return err
+				}
+			case fileInfo.Mode().IsRegular():
+				exportEntry.mode = 0644
+				if fileInfo.Mode()&0111 != 0 {
+					exportEntry.mode = 0755
+				}
+			default:
+				// sockets, devices and pipes are not part of a checkout
+				return nil
+			}
+			exportEntries = append(exportEntries, exportEntry)
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+	return exportEntries, nil
+}
+
+func writeExportTar(exportEntries []*exportEntry, writer io.Writer) error {
+	tarWriter := tar.NewWriter(writer)
+	for _, exportEntry := range exportEntries {
+		header := &tar.Header{
+			Name:    exportEntry.name,
+			Mode:    int64(exportEntry.mode.Perm()),
+			ModTime: exportModTime,
+		}
+		switch {
+		case exportEntry.mode.IsDir():
+			header.Typeflag = tar.TypeDir
+		case exportEntry.mode&os.ModeSymlink != 0:
+			header.Typeflag = tar.TypeSymlink
+			header.Linkname = exportEntry.link
+		default:
+			header.Typeflag = tar.TypeReg
+		}
+		if header.Typeflag != tar.TypeReg {
+			if err := tarWriter.WriteHeader(header); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := writeExportFile(exportEntry.path, func(size int64) (io.Writer, error) {
+			header.Size = size
+			return tarWriter, tarWriter.WriteHeader(header)
+		}); err != nil {
+			return err
+		}
+	}
+	return tarWriter.Close()
+}
+
+func writeExportZip(exportEntries []*exportEntry, writer io.Writer) error {
+	zipWriter := zip.NewWriter(writer)
+	for _, exportEntry := range exportEntries {
+		header := &zip.FileHeader{
+			Name:     exportEntry.name,
+			Method:   zip.Deflate,
+			Modified: exportModTime,
+		}
+		header.SetMode(exportEntry.mode)
+		switch {
+		case exportEntry.mode.IsDir():
+			header.Method = zip.Store
+			if _, err := zipWriter.CreateHeader(header); err != nil {
+				return err
+			}
+		case exportEntry.mode&os.ModeSymlink != 0:
+			fileWriter, err := zipWriter.CreateHeader(header)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(fileWriter, exportEntry.link); err != nil {
+				return err
+			}
+		default:
+			if err := writeExportFile(exportEntry.path, func(int64) (io.Writer, error) {
+				return zipWriter.CreateHeader(header)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return zipWriter.Close()
+}
+
+// writeExportFile copies the file at path to the writer returned by getWriter, which is given the size of the file.
+func writeExportFile(path string, getWriter func(int64) (io.Writer, error)) (retErr error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	writer, err := getWriter(fileInfo.Size())
+	if err != nil {
+		return err
+	}
+	_, err = io.CopyN(writer, file, fileInfo.Size())
+	return err
+}
@@ -0,0 +1,228 @@
+package scm
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	// not parallel since submodules from local paths need protocol.file.allow in the environment
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+	checkoutOptions, commitID := newTestExportRepository(t)
+
+	for _, exportFormat := range []ExportFormat{ExportFormatTar, ExportFormatTarGz, ExportFormatZip} {
+		var first bytes.Buffer
+		checkoutResult, err := Export(checkoutOptions, &first, exportFormat, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", exportFormat, err)
+		}
+		if checkoutResult.Revision != commitID {
+			t.Errorf("%s: expected revision %s, got %s", exportFormat, commitID, checkoutResult.Revision)
+		}
+		var second bytes.Buffer
+		if _, err := Export(checkoutOptions, &second, exportFormat, &ExportOptions{}); err != nil {
+			t.Fatalf("%s: %v", exportFormat, err)
+		}
+		if !bytes.Equal(first.Bytes(), second.Bytes()) {
+			t.Errorf("%s: expected identical archives", exportFormat)
+		}
+		entries := readTestExport(t, exportFormat, first.Bytes())
+		for name, expected := range map[string]string{
+			"README.md":     "0644 banana\n",
+			"bin/":          "0755 ",
+			"bin/run.sh":    "0755 #!/bin/sh\n",
+			"link":          "0777 -> README.md",
+			"sub/":          "0755 ",
+			"sub/README.md": "0644 banana\n",
+			".gitmodules":   "",
+			".git/":         "missing",
+			"sub/.git":      "missing",
+		} {
+			actual, ok := entries[name]
+			switch {
+			case expected == "missing":
+				if ok {
+					t.Errorf("%s: expected no entry for %s", exportFormat, name)
+				}
+			case !ok:
+				t.Errorf("%s: expected an entry for %s in %v", exportFormat, name, entries)
+			case expected != "" && actual != expected:
+				t.Errorf("%s: %s: expected %q, got %q", exportFormat, name, expected, actual)
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	if _, err := Export(checkoutOptions, &buffer, ExportFormatTar, &ExportOptions{IncludeVCSMetadata: true}); err != nil {
+		t.Fatal(err)
+	}
+	entries := readTestExport(t, ExportFormatTar, buffer.Bytes())
+	if _, ok := entries[".git/HEAD"]; !ok {
+		t.Errorf("expected .git/HEAD in %v", entries)
+	}
+}
+
+func TestExportFossil(t *testing.T) {
+	t.Parallel()
+	// a Fossil checkout holds the clone of the repository database, which records when it was cloned
+	var archives [][]byte
+	for _, repository := range []string{"first clone", "second clone"} {
+		dirPath := newTestFossilCheckoutDir(t, repository)
+		var buffer bytes.Buffer
+		if err := writeExport(dirPath, &buffer, ExportFormatTar, false); err != nil {
+			t.Fatal(err)
+		}
+		archives = append(archives, buffer.Bytes())
+	}
+	if !bytes.Equal(archives[0], archives[1]) {
+		t.Error("expected identical archives")
+	}
+	entries := readTestExport(t, ExportFormatTar, archives[0])
+	if len(entries) != 1 || entries["README.md"] != "0644 banana\n" {
+		t.Errorf("expected only README.md, got %v", entries)
+	}
+	var buffer bytes.Buffer
+	if err := writeExport(newTestFossilCheckoutDir(t, "clone"), &buffer, ExportFormatTar, true); err != nil {
+		t.Fatal(err)
+	}
+	entries = readTestExport(t, ExportFormatTar, buffer.Bytes())
+	for _, name := range []string{fossilRepositoryFile, ".fslckout"} {
+		if _, ok := entries[name]; !ok {
+			t.Errorf("expected %s in %v", name, entries)
+		}
+	}
+}
+
+func TestExportFormatOf(t *testing.T) {
+	t.Parallel()
+	for _, s := range []string{"tar", "tar.gz", "zip"} {
+		exportFormat, err := ExportFormatOf(s)
+		if err != nil {
+			t.Error(err)
+		}
+		if string(exportFormat) != s {
+			t.Errorf("expected %s, got %s", s, exportFormat)
+		}
+	}
+	if _, err := ExportFormatOf("rar"); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := Export(&LocalCheckoutOptions{}, ioutil.Discard, ExportFormat("rar"), nil); err == nil || !strings.Contains(err.Error(), "UnknownExportFormat") {
+		t.Errorf("expected UnknownExportFormat, got %v", err)
+	}
+}
+
+// newTestExportRepository creates a repository with an executable, a symlink and a submodule,
+// and returns the LocalCheckoutOptions for it and the ID of its commit.
+func newTestExportRepository(t *testing.T) (*LocalCheckoutOptions, string) {
+	submoduleDir, _ := newTestGitRepository(t)
+	workDir, _ := newTestGitRepository(t)
+	if err := os.Mkdir(filepath.Join(workDir, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(workDir, "bin", "run.sh"), []byte("#!/bin/sh\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("README.md", filepath.Join(workDir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	runTestCommand(t, workDir, "git", "submodule", "add", "-q", submoduleDir, "sub")
+	runTestCommand(t, workDir, "git", "add", "bin", "link")
+	runTestCommand(t, workDir, "git", "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "export")
+	commitID := strings.TrimSpace(runTestCommand(t, workDir, "git", "rev-parse", "HEAD"))
+	return &LocalCheckoutOptions{
+		Path:     workDir,
+		Branch:   "master",
+		CommitID: commitID,
+	}, commitID
+}
+
+// newTestFossilCheckoutDir creates a directory laid out like a Fossil checkout, with repository
+// as the content of the repository database.
+func newTestFossilCheckoutDir(t *testing.T, repository string) string {
+	dirPath := getTempDir(t)
+	for name, content := range map[string]string{
+		"README.md":          "banana\n",
+		fossilRepositoryFile: repository,
+		".fslckout":          repository,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dirPath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dirPath
+}
+
+// readTestExport returns the entries of an archive as "<permissions> <content>", with "-> <target>" as the content of symlinks.
+func readTestExport(t *testing.T, exportFormat ExportFormat, data []byte) map[string]string {
+	entries := make(map[string]string)
+	if exportFormat == ExportFormatZip {
+		zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range zipReader.File {
+			if !file.Modified.Equal(exportModTime) {
+				t.Errorf("%s: expected modification time %v, got %v", file.Name, exportModTime, file.Modified)
+			}
+			readCloser, err := file.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			content, err := ioutil.ReadAll(readCloser)
+			readCloser.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries[file.Name] = getTestExportEntry(file.Mode(), string(content))
+		}
+		return entries
+	}
+	var reader io.Reader = bytes.NewReader(data)
+	if exportFormat == ExportFormatTarGz {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = gzipReader
+	}
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !header.ModTime.Equal(exportModTime) || header.Uid != 0 || header.Gid != 0 || header.Uname != "" || header.Gname != "" {
+			t.Errorf("%s: expected normalized header, got %+v", header.Name, header)
+		}
+		content, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag == tar.TypeSymlink {
+			content = []byte(header.Linkname)
+		}
+		entries[header.Name] = getTestExportEntry(header.FileInfo().Mode(), string(content))
+	}
+}
+
+func getTestExportEntry(mode os.FileMode, content string) string {
+	if mode&os.ModeSymlink != 0 {
+		content = "-> " + content
+	}
+	return fmt.Sprintf("%04o %s", mode.Perm(), content)
+}
@@ -0,0 +1,442 @@
+package scm
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	// ExternalAPIVersionV1 is the apiVersion of ExternalCheckoutOptions documents.
+	// Documents without an apiVersion are v1.
+	ExternalAPIVersionV1 = "v1"
+	// ExternalAPIVersionV2 is the apiVersion of ExternalCheckoutOptionsV2 documents.
+	ExternalAPIVersionV2 = "v2"
+)
+
+// ExternalCheckoutOptionsV2 is the v2 external format. Provider-specific fields are
+// grouped under the block named after the type, such as github for type github, and
+// only that block may be set.
+type ExternalCheckoutOptionsV2 struct {
+	APIVersion      string                    `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+	Type            string                    `json:"type,omitempty" yaml:"type,omitempty"`
+	Commit          *ExternalCommitV2         `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Committer       *ExternalCommitterV2      `json:"committer,omitempty" yaml:"committer,omitempty"`
+	Git             *ExternalHostV2           `json:"git,omitempty" yaml:"git,omitempty"`
+	Github          *ExternalRepositoryV2     `json:"github,omitempty" yaml:"github,omitempty"`
+	Hg              *ExternalHostV2           `json:"hg,omitempty" yaml:"hg,omitempty"`
+	BitbucketGit    *ExternalRepositoryV2     `json:"bitbucketGit,omitempty" yaml:"bitbucketGit,omitempty"`
+	BitbucketHg     *ExternalRepositoryV2     `json:"bitbucketHg,omitempty" yaml:"bitbucketHg,omitempty"`
+	Gitlab          *ExternalRepositoryV2     `json:"gitlab,omitempty" yaml:"gitlab,omitempty"`
+	Gitea           *ExternalGiteaV2          `json:"gitea,omitempty" yaml:"gitea,omitempty"`
+	AzureDevOps     *ExternalAzureDevOpsV2    `json:"azureDevOps,omitempty" yaml:"azureDevOps,omitempty"`
+	CodeCommit      *ExternalCodeCommitV2     `json:"codeCommit,omitempty" yaml:"codeCommit,omitempty"`
+	Svn             *ExternalSvnV2            `json:"svn,omitempty" yaml:"svn,omitempty"`
+	Fossil          *ExternalFossilV2         `json:"fossil,omitempty" yaml:"fossil,omitempty"`
+	Local           *ExternalLocalV2          `json:"local,omitempty" yaml:"local,omitempty"`
+	SparsePaths     []string                  `json:"sparse_paths,omitempty" yaml:"sparse_paths,omitempty"`
+	CloneFilter     string                    `json:"clone_filter,omitempty" yaml:"clone_filter,omitempty"`
+	Submodules      *ExternalSubmoduleOptions `json:"submodules,omitempty" yaml:"submodules,omitempty"`
+	LFS             *ExternalLFSOptions       `json:"lfs,omitempty" yaml:"lfs,omitempty"`
+	SecurityOptions *ExternalSecurityOptions  `json:"security_options,omitempty" yaml:"security_options,omitempty"`
+}
+
+// ExternalCommitV2 identifies what to check out. Which fields apply depends on the type.
+type ExternalCommitV2 struct {
+	Branch      string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Ref         string `json:"ref,omitempty" yaml:"ref,omitempty"`
+	CommitID    string `json:"commit_id,omitempty" yaml:"commit_id,omitempty"`
+	ChangesetID string `json:"changeset_id,omitempty" yaml:"changeset_id,omitempty"`
+	Revision    string `json:"revision,omitempty" yaml:"revision,omitempty"`
+	CheckinID   string `json:"checkin_id,omitempty" yaml:"checkin_id,omitempty"`
+	Message     string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+type ExternalCommitterV2 struct {
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+	Email    string `json:"email,omitempty" yaml:"email,omitempty"`
+}
+
+// ExternalHostV2 is the block for git and hg.
+type ExternalHostV2 struct {
+	User string `json:"user,omitempty" yaml:"user,omitempty"`
+	Host string `json:"host,omitempty" yaml:"host,omitempty"`
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// ExternalRepositoryV2 is the block for github, bitbucketGit, bitbucketHg and gitlab.
+type ExternalRepositoryV2 struct {
+	User       string `json:"user,omitempty" yaml:"user,omitempty"`
+	Repository string `json:"repository,omitempty" yaml:"repository,omitempty"`
+}
+
+type ExternalGiteaV2 struct {
+	User       string `json:"user,omitempty" yaml:"user,omitempty"`
+	Host       string `json:"host,omitempty" yaml:"host,omitempty"`
+	Repository string `json:"repository,omitempty" yaml:"repository,omitempty"`
+}
+
+type ExternalAzureDevOpsV2 struct {
+	Organization string `json:"organization,omitempty" yaml:"organization,omitempty"`
+	Project      string `json:"project,omitempty" yaml:"project,omitempty"`
+	Repository   string `json:"repository,omitempty" yaml:"repository,omitempty"`
+}
+
+type ExternalCodeCommitV2 struct {
+	Region     string `json:"region,omitempty" yaml:"region,omitempty"`
+	Repository string `json:"repository,omitempty" yaml:"repository,omitempty"`
+	SSHKeyID   string `json:"ssh_key_id,omitempty" yaml:"ssh_key_id,omitempty"`
+}
+
+type ExternalSvnV2 struct {
+	URL      string `json:"url,omitempty" yaml:"url,omitempty"`
+	Path     string `json:"path,omitempty" yaml:"path,omitempty"`
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
+}
+
+type ExternalFossilV2 struct {
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+type ExternalLocalV2 struct {
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// ReadExternalCheckoutOptions reads a v1 or v2 document using unmarshal, which decodes the
+// document into the value it is given, for example
+// func(v interface{}) error { return json.Unmarshal(data, v) }.
+// v1 documents are upgraded to v2.
+func ReadExternalCheckoutOptions(unmarshal func(interface{}) error) (*ExternalCheckoutOptionsV2, error) {
+	return readExternalCheckoutOptions(unmarshal)
+}
+
+// UpgradeExternalCheckoutOptions converts a v1 document to v2. Fields that do not apply
+// to the type cannot be represented in v2 and are reported as ValidationErrors.
+func UpgradeExternalCheckoutOptions(externalCheckoutOptions *ExternalCheckoutOptions) (*ExternalCheckoutOptionsV2, error) {
+	return upgradeExternalCheckoutOptions(externalCheckoutOptions)
+}
+
+// DowngradeExternalCheckoutOptions converts a v2 document to v1.
+func DowngradeExternalCheckoutOptions(externalCheckoutOptionsV2 *ExternalCheckoutOptionsV2) (*ExternalCheckoutOptions, error) {
+	return downgradeExternalCheckoutOptions(externalCheckoutOptionsV2)
+}
+
+func ConvertCheckoutOptionsV2(checkoutOptions CheckoutOptions) (*ExternalCheckoutOptionsV2, error) {
+	externalCheckoutOptions, err := convertCheckoutOptions(checkoutOptions)
+	if err != nil {
+		return nil, err
+	}
+	return upgradeExternalCheckoutOptions(externalCheckoutOptions)
+}
+
+func ConvertExternalCheckoutOptionsV2(externalCheckoutOptionsV2 *ExternalCheckoutOptionsV2) (CheckoutOptions, error) {
+	externalCheckoutOptions, err := downgradeExternalCheckoutOptions(externalCheckoutOptionsV2)
+	if err != nil {
+		return nil, err
+	}
+	return convertExternalCheckoutOptions(externalCheckoutOptions)
+}
+
+// ***** PRIVATE *****
+
+type externalAPIVersion struct {
+	APIVersion string `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+}
+
+func readExternalCheckoutOptions(unmarshal func(interface{}) error) (*ExternalCheckoutOptionsV2, error) {
+	var apiVersion externalAPIVersion
+	if err := unmarshal(&apiVersion); err != nil {
+		return nil, err
+	}
+	switch apiVersion.APIVersion {
+	case "", ExternalAPIVersionV1:
+		externalCheckoutOptions := &ExternalCheckoutOptions{}
+		if err := unmarshal(externalCheckoutOptions); err != nil {
+			return nil, err
+		}
+		return upgradeExternalCheckoutOptions(externalCheckoutOptions)
+	case ExternalAPIVersionV2:
+		externalCheckoutOptionsV2 := &ExternalCheckoutOptionsV2{}
+		if err := unmarshal(externalCheckoutOptionsV2); err != nil {
+			return nil, err
+		}
+		return externalCheckoutOptionsV2, nil
+	default:
+		return nil, newErrorUnknownExternalAPIVersion(apiVersion.APIVersion)
+	}
+}
+
+func upgradeExternalCheckoutOptions(externalCheckoutOptions *ExternalCheckoutOptions) (*ExternalCheckoutOptionsV2, error) {
+	checkoutOptionsType, err := CheckoutOptionsTypeOf(externalCheckoutOptions.Type)
+	if err != nil {
+		return nil, err
+	}
+	externalCheckoutOptionsV2 := &ExternalCheckoutOptionsV2{
+		APIVersion:      ExternalAPIVersionV2,
+		Type:            externalCheckoutOptions.Type,
+		SparsePaths:     externalCheckoutOptions.SparsePaths,
+		CloneFilter:     externalCheckoutOptions.CloneFilter,
+		Submodules:      externalCheckoutOptions.Submodules,
+		LFS:             externalCheckoutOptions.LFS,
+		SecurityOptions: externalCheckoutOptions.SecurityOptions,
+	}
+	commit := &ExternalCommitV2{
+		Branch:      externalCheckoutOptions.Branch,
+		Ref:         externalCheckoutOptions.Ref,
+		CommitID:    externalCheckoutOptions.CommitID,
+		ChangesetID: externalCheckoutOptions.ChangesetID,
+		Revision:    externalCheckoutOptions.Revision,
+		CheckinID:   externalCheckoutOptions.CheckinID,
+		Message:     externalCheckoutOptions.CommitMessage,
+	}
+	if *commit != (ExternalCommitV2{}) {
+		externalCheckoutOptionsV2.Commit = commit
+	}
+	committer := &ExternalCommitterV2{
+		Name:     externalCheckoutOptions.CommitterName,
+		Username: externalCheckoutOptions.CommitterUsername,
+		Email:    externalCheckoutOptions.Email,
+	}
+	if *committer != (ExternalCommitterV2{}) {
+		externalCheckoutOptionsV2.Committer = committer
+	}
+	host := &ExternalHostV2{
+		User: externalCheckoutOptions.User,
+		Host: externalCheckoutOptions.Host,
+		Path: externalCheckoutOptions.Path,
+	}
+	repository := &ExternalRepositoryV2{
+		User:       externalCheckoutOptions.User,
+		Repository: externalCheckoutOptions.Repository,
+	}
+	if err := checkoutOptionsType.Handle(
+		func() error {
+			externalCheckoutOptionsV2.Git = host
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.Github = repository
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.Hg = host
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.BitbucketGit = repository
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.BitbucketHg = repository
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.Gitlab = repository
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.Gitea = &ExternalGiteaV2{
+				User:       externalCheckoutOptions.User,
+				Host:       externalCheckoutOptions.Host,
+				Repository: externalCheckoutOptions.Repository,
+			}
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.AzureDevOps = &ExternalAzureDevOpsV2{
+				Organization: externalCheckoutOptions.Organization,
+				Project:      externalCheckoutOptions.Project,
+				Repository:   externalCheckoutOptions.Repository,
+			}
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.CodeCommit = &ExternalCodeCommitV2{
+				Region:     externalCheckoutOptions.Region,
+				Repository: externalCheckoutOptions.Repository,
+				SSHKeyID:   externalCheckoutOptions.SSHKeyID,
+			}
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.Svn = &ExternalSvnV2{
+				URL:      externalCheckoutOptions.URL,
+				Path:     externalCheckoutOptions.Path,
+				Username: externalCheckoutOptions.Username,
+				Password: externalCheckoutOptions.Password,
+			}
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.Fossil = &ExternalFossilV2{
+				URL: externalCheckoutOptions.URL,
+			}
+			return nil
+		},
+		func() error {
+			externalCheckoutOptionsV2.Local = &ExternalLocalV2{
+				Path: externalCheckoutOptions.Path,
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+	// anything that does not survive the round trip has no place in the v2 block for the type
+	downgradedExternalCheckoutOptions, err := downgradeExternalCheckoutOptions(externalCheckoutOptionsV2)
+	if err != nil {
+		return nil, err
+	}
+	var validationErrors ValidationErrors
+	expectedValue := reflect.ValueOf(externalCheckoutOptions).Elem()
+	actualValue := reflect.ValueOf(downgradedExternalCheckoutOptions).Elem()
+	for i := 0; i < expectedValue.NumField(); i++ {
+		if !reflect.DeepEqual(expectedValue.Field(i).Interface(), actualValue.Field(i).Interface()) {
+			validationErrors = append(validationErrors, newValidationErrorFieldShouldNotBeSet("*ExternalCheckoutOptions", expectedValue.Type().Field(i).Name))
+		}
+	}
+	if err := validationErrors.errorOrNil(); err != nil {
+		return nil, err
+	}
+	return externalCheckoutOptionsV2, nil
+}
+
+func downgradeExternalCheckoutOptions(externalCheckoutOptionsV2 *ExternalCheckoutOptionsV2) (*ExternalCheckoutOptions, error) {
+	if externalCheckoutOptionsV2.APIVersion != ExternalAPIVersionV2 {
+		return nil, newErrorUnknownExternalAPIVersion(externalCheckoutOptionsV2.APIVersion)
+	}
+	checkoutOptionsType, err := CheckoutOptionsTypeOf(externalCheckoutOptionsV2.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateExternalCheckoutOptionsV2Blocks(externalCheckoutOptionsV2); err != nil {
+		return nil, err
+	}
+	externalCheckoutOptions := &ExternalCheckoutOptions{
+		Type:            externalCheckoutOptionsV2.Type,
+		SparsePaths:     externalCheckoutOptionsV2.SparsePaths,
+		CloneFilter:     externalCheckoutOptionsV2.CloneFilter,
+		Submodules:      externalCheckoutOptionsV2.Submodules,
+		LFS:             externalCheckoutOptionsV2.LFS,
+		SecurityOptions: externalCheckoutOptionsV2.SecurityOptions,
+	}
+	if commit := externalCheckoutOptionsV2.Commit; commit != nil {
+		externalCheckoutOptions.Branch = commit.Branch
+		externalCheckoutOptions.Ref = commit.Ref
+		externalCheckoutOptions.CommitID = commit.CommitID
+		externalCheckoutOptions.ChangesetID = commit.ChangesetID
+		externalCheckoutOptions.Revision = commit.Revision
+		externalCheckoutOptions.CheckinID = commit.CheckinID
+		externalCheckoutOptions.CommitMessage = commit.Message
+	}
+	if committer := externalCheckoutOptionsV2.Committer; committer != nil {
+		externalCheckoutOptions.CommitterName = committer.Name
+		externalCheckoutOptions.CommitterUsername = committer.Username
+		externalCheckoutOptions.Email = committer.Email
+	}
+	setHost := func(host *ExternalHostV2) error {
+		if host != nil {
+			externalCheckoutOptions.User = host.User
+			externalCheckoutOptions.Host = host.Host
+			externalCheckoutOptions.Path = host.Path
+		}
+		return nil
+	}
+	setRepository := func(repository *ExternalRepositoryV2) error {
+		if repository != nil {
+			externalCheckoutOptions.User = repository.User
+			externalCheckoutOptions.Repository = repository.Repository
+		}
+		return nil
+	}
+	if err := checkoutOptionsType.Handle(
+		func() error {
+			return setHost(externalCheckoutOptionsV2.Git)
+		},
+		func() error {
+			return setRepository(externalCheckoutOptionsV2.Github)
+		},
+		func() error {
+			return setHost(externalCheckoutOptionsV2.Hg)
+		},
+		func() error {
+			return setRepository(externalCheckoutOptionsV2.BitbucketGit)
+		},
+		func() error {
+			return setRepository(externalCheckoutOptionsV2.BitbucketHg)
+		},
+		func() error {
+			return setRepository(externalCheckoutOptionsV2.Gitlab)
+		},
+		func() error {
+			if gitea := externalCheckoutOptionsV2.Gitea; gitea != nil {
+				externalCheckoutOptions.User = gitea.User
+				externalCheckoutOptions.Host = gitea.Host
+				externalCheckoutOptions.Repository = gitea.Repository
+			}
+			return nil
+		},
+		func() error {
+			if azureDevOps := externalCheckoutOptionsV2.AzureDevOps; azureDevOps != nil {
+				externalCheckoutOptions.Organization = azureDevOps.Organization
+				externalCheckoutOptions.Project = azureDevOps.Project
+				externalCheckoutOptions.Repository = azureDevOps.Repository
+			}
+			return nil
+		},
+		func() error {
+			if codeCommit := externalCheckoutOptionsV2.CodeCommit; codeCommit != nil {
+				externalCheckoutOptions.Region = codeCommit.Region
+				externalCheckoutOptions.Repository = codeCommit.Repository
+				externalCheckoutOptions.SSHKeyID = codeCommit.SSHKeyID
+			}
+			return nil
+		},
+		func() error {
+			if svn := externalCheckoutOptionsV2.Svn; svn != nil {
+				externalCheckoutOptions.URL = svn.URL
+				externalCheckoutOptions.Path = svn.Path
+				externalCheckoutOptions.Username = svn.Username
+				externalCheckoutOptions.Password = svn.Password
+			}
+			return nil
+		},
+		func() error {
+			if fossil := externalCheckoutOptionsV2.Fossil; fossil != nil {
+				externalCheckoutOptions.URL = fossil.URL
+			}
+			return nil
+		},
+		func() error {
+			if local := externalCheckoutOptionsV2.Local; local != nil {
+				externalCheckoutOptions.Path = local.Path
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+	return externalCheckoutOptions, nil
+}
+
+// validateExternalCheckoutOptionsV2Blocks rejects every provider block other than the one named after the type.
+func validateExternalCheckoutOptionsV2Blocks(externalCheckoutOptionsV2 *ExternalCheckoutOptionsV2) error {
+	var validationErrors ValidationErrors
+	value := reflect.ValueOf(externalCheckoutOptionsV2).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		blockName := getJSONName(field)
+		if _, err := CheckoutOptionsTypeOf(blockName); err != nil {
+			continue
+		}
+		if blockName != externalCheckoutOptionsV2.Type && !value.Field(i).IsNil() {
+			validationErrors = append(validationErrors, newValidationErrorFieldShouldNotBeSet("*ExternalCheckoutOptionsV2", field.Name))
+		}
+	}
+	return validationErrors.errorOrNil()
+}
+
+func newErrorUnknownExternalAPIVersion(apiVersion string) error {
+	return fmt.Errorf("scm: UnknownExternalAPIVersion: %v", apiVersion)
+}
@@ -0,0 +1,163 @@
+package scm
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestReadExternalCheckoutOptions(t *testing.T) {
+	t.Parallel()
+	expected := &ExternalCheckoutOptionsV2{
+		APIVersion: ExternalAPIVersionV2,
+		Type:       "github",
+		Commit: &ExternalCommitV2{
+			Branch:   "master",
+			CommitID: testSmartystreetsCommitID,
+		},
+		Committer: &ExternalCommitterV2{
+			Name:     "Peter Edge",
+			Username: "peter-edge",
+		},
+		Github: &ExternalRepositoryV2{
+			User:       "peter-edge",
+			Repository: "smartystreets_ruby",
+		},
+		SecurityOptions: &ExternalSecurityOptions{
+			Type:        "accessToken",
+			AccessToken: "token",
+		},
+	}
+	for _, document := range []string{
+		`{
+			"type": "github",
+			"user": "peter-edge",
+			"repository": "smartystreets_ruby",
+			"branch": "master",
+			"commit_id": "` + testSmartystreetsCommitID + `",
+			"committer_name": "Peter Edge",
+			"committer_username": "peter-edge",
+			"security_options": {"type": "accessToken", "access_token": "token"}
+		}`,
+		`{
+			"apiVersion": "v1",
+			"type": "github",
+			"user": "peter-edge",
+			"repository": "smartystreets_ruby",
+			"branch": "master",
+			"commit_id": "` + testSmartystreetsCommitID + `",
+			"committer_name": "Peter Edge",
+			"committer_username": "peter-edge",
+			"security_options": {"type": "accessToken", "access_token": "token"}
+		}`,
+		`{
+			"apiVersion": "v2",
+			"type": "github",
+			"github": {"user": "peter-edge", "repository": "smartystreets_ruby"},
+			"commit": {"branch": "master", "commit_id": "` + testSmartystreetsCommitID + `"},
+			"committer": {"name": "Peter Edge", "username": "peter-edge"},
+			"security_options": {"type": "accessToken", "access_token": "token"}
+		}`,
+	} {
+		data := []byte(document)
+		externalCheckoutOptionsV2, err := ReadExternalCheckoutOptions(func(v interface{}) error { return json.Unmarshal(data, v) })
+		if err != nil {
+			t.Errorf("%s: %v", document, err)
+			continue
+		}
+		if !reflect.DeepEqual(expected, externalCheckoutOptionsV2) {
+			t.Errorf("%s: expected %+v, got %+v", document, expected, externalCheckoutOptionsV2)
+		}
+	}
+	data := []byte(`{"apiVersion": "v3", "type": "github"}`)
+	if _, err := ReadExternalCheckoutOptions(func(v interface{}) error { return json.Unmarshal(data, v) }); err == nil {
+		t.Error("expected error for unknown apiVersion")
+	}
+}
+
+func TestUpgradeExternalCheckoutOptionsRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, externalCheckoutOptions := range []*ExternalCheckoutOptions{
+		{Type: "git", User: "git", Host: "github.com", Path: ":peter-edge/smartystreets_ruby.git", Branch: "master", CommitID: testSmartystreetsCommitID, SecurityOptions: &ExternalSecurityOptions{Type: "ssh", PrivateKey: "key"}},
+		{Type: "github", User: "peter-edge", Repository: "smartystreets_ruby", Branch: "master", CommitID: testSmartystreetsCommitID, CommitMessage: "message", Email: "peter@example.com"},
+		{Type: "hg", User: "hg", Host: "bitbucket.org", Path: "/durin42/hg-git", ChangesetID: testHgGitChangesetID},
+		{Type: "bitbucketGit", User: "owner", Repository: "repo", Branch: "master", CommitID: testBananaCommitID},
+		{Type: "bitbucketHg", User: "durin42", Repository: "hg-git", ChangesetID: testHgGitChangesetID},
+		{Type: "gitlab", User: "group/sub", Repository: "banana", Branch: "master", CommitID: testBananaCommitID},
+		{Type: "gitlab", User: "group/sub", Repository: "banana", Ref: "refs/merge-requests/1/head"},
+		{Type: "gitea", User: "codeship", Host: "gitea.example.com", Repository: "banana", Branch: "master", CommitID: testBananaCommitID},
+		{Type: "azureDevOps", Organization: "codeship", Project: "fruit", Repository: "banana", Branch: "master", CommitID: testBananaCommitID},
+		{Type: "codeCommit", Region: "us-east-1", Repository: "banana", SSHKeyID: "APKAEIBAERJR2EXAMPLE", Branch: "master", CommitID: testBananaCommitID, SecurityOptions: &ExternalSecurityOptions{Type: "ssh"}},
+		{Type: "svn", URL: "https://svn.example.com/repo", Path: "/trunk", Revision: "1", Username: "user", Password: "password"},
+		{Type: "fossil", URL: "https://fossil.example.com/banana", CheckinID: "trunk"},
+		{Type: "local", Path: "/srv/git/banana.git", Branch: "master", CommitID: testBananaCommitID},
+	} {
+		externalCheckoutOptionsV2, err := UpgradeExternalCheckoutOptions(externalCheckoutOptions)
+		if err != nil {
+			t.Errorf("%+v: %v", externalCheckoutOptions, err)
+			continue
+		}
+		downgradedExternalCheckoutOptions, err := DowngradeExternalCheckoutOptions(externalCheckoutOptionsV2)
+		if err != nil {
+			t.Errorf("%+v: %v", externalCheckoutOptions, err)
+			continue
+		}
+		if !reflect.DeepEqual(externalCheckoutOptions, downgradedExternalCheckoutOptions) {
+			t.Errorf("expected %+v, got %+v", externalCheckoutOptions, downgradedExternalCheckoutOptions)
+		}
+		checkoutOptions, err := ConvertExternalCheckoutOptionsV2(externalCheckoutOptionsV2)
+		if err != nil {
+			t.Errorf("%+v: %v", externalCheckoutOptions, err)
+			continue
+		}
+		convertedExternalCheckoutOptionsV2, err := ConvertCheckoutOptionsV2(checkoutOptions)
+		if err != nil {
+			t.Errorf("%+v: %v", externalCheckoutOptions, err)
+			continue
+		}
+		if !reflect.DeepEqual(externalCheckoutOptionsV2, convertedExternalCheckoutOptionsV2) {
+			t.Errorf("expected %+v, got %+v", externalCheckoutOptionsV2, convertedExternalCheckoutOptionsV2)
+		}
+	}
+}
+
+func TestUpgradeExternalCheckoutOptionsFieldShouldNotBeSet(t *testing.T) {
+	t.Parallel()
+	_, err := UpgradeExternalCheckoutOptions(
+		&ExternalCheckoutOptions{
+			Type:       "github",
+			User:       "peter-edge",
+			Host:       "github.com",
+			Repository: "smartystreets_ruby",
+		},
+	)
+	validationErrors, ok := err.(ValidationErrors)
+	if !ok || len(validationErrors) != 1 {
+		t.Fatalf("expected one ValidationError, got %v", err)
+	}
+	if validationErrors[0].Type() != ValidationErrorTypeFieldShouldNotBeSet || validationErrors[0].FieldPath() != "Host" {
+		t.Errorf("expected FieldShouldNotBeSet for Host, got %v", validationErrors[0])
+	}
+}
+
+func TestDowngradeExternalCheckoutOptionsFieldShouldNotBeSet(t *testing.T) {
+	t.Parallel()
+	_, err := DowngradeExternalCheckoutOptions(
+		&ExternalCheckoutOptionsV2{
+			APIVersion: ExternalAPIVersionV2,
+			Type:       "github",
+			Github:     &ExternalRepositoryV2{User: "peter-edge", Repository: "smartystreets_ruby"},
+			Gitea:      &ExternalGiteaV2{Host: "gitea.example.com"},
+		},
+	)
+	validationErrors, ok := err.(ValidationErrors)
+	if !ok || len(validationErrors) != 1 {
+		t.Fatalf("expected one ValidationError, got %v", err)
+	}
+	if validationErrors[0].Type() != ValidationErrorTypeFieldShouldNotBeSet || validationErrors[0].FieldPath() != "Gitea" {
+		t.Errorf("expected FieldShouldNotBeSet for Gitea, got %v", validationErrors[0])
+	}
+	if _, err := DowngradeExternalCheckoutOptions(&ExternalCheckoutOptionsV2{Type: "github"}); err == nil {
+		t.Error("expected error for missing apiVersion")
+	}
+}
@@ -0,0 +1,144 @@
+package scm
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxHostnameLength             = 253
+	maxCodeCommitRepositoryLength = 100
+)
+
+var (
+	// git accepts abbreviations of at least four hex digits, up to a full SHA-1 (40) or SHA-256 (64) object name.
+	commitIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{4,64}$`)
+	// hg accepts any unique prefix of the 40 hex digit node ID; a short ID is 12.
+	changesetIDRegexp    = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+	hostnameLabelRegexp  = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+	repositorySlugRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+	awsRegionRegexp      = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+	// the filters git clone --filter accepts that are useful for a checkout; blob:limit takes an optional k, m or g suffix.
+	cloneFilterRegexp = regexp.MustCompile(`^(blob:none|tree:0|blob:limit=[0-9]+[kmg]?)$`)
+)
+
+func isValidCommitID(commitID string) bool {
+	return commitIDRegexp.MatchString(commitID)
+}
+
+func isValidChangesetID(changesetID string) bool {
+	return changesetIDRegexp.MatchString(changesetID)
+}
+
+// isValidBranchName follows the rules of git check-ref-format --branch.
+func isValidBranchName(branch string) bool {
+	if branch == "" || branch == "@" || branch == "HEAD" {
+		return false
+	}
+	if strings.HasPrefix(branch, "-") || strings.HasPrefix(branch, "/") || strings.HasSuffix(branch, "/") || strings.HasSuffix(branch, ".") {
+		return false
+	}
+	if strings.Contains(branch, "..") || strings.Contains(branch, "//") || strings.Contains(branch, "@{") {
+		return false
+	}
+	for _, c := range branch {
+		if c < 0x20 || c == 0x7f || strings.ContainsRune(" ~^:?*[\\", c) {
+			return false
+		}
+	}
+	for _, component := range strings.Split(branch, "/") {
+		if strings.HasPrefix(component, ".") || strings.HasSuffix(component, ".lock") {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidHost accepts a hostname or IP address with an optional port.
+func isValidHost(host string) bool {
+	hostname := host
+	if splitHost, port, err := net.SplitHostPort(host); err == nil {
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return false
+		}
+		hostname = splitHost
+	} else if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		hostname = host[1 : len(host)-1]
+	}
+	if net.ParseIP(hostname) != nil {
+		return true
+	}
+	return isValidHostname(hostname)
+}
+
+func isValidHostname(hostname string) bool {
+	if hostname == "" || len(hostname) > maxHostnameLength {
+		return false
+	}
+	for _, label := range strings.Split(hostname, ".") {
+		if !hostnameLabelRegexp.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidRepositorySlug accepts the owner and repository names used by GitHub, Bitbucket, GitLab and Gitea.
+func isValidRepositorySlug(slug string) bool {
+	return slug != "." && slug != ".." && repositorySlugRegexp.MatchString(slug)
+}
+
+// isValidRepositoryNamespace accepts a GitLab group path, which may contain subgroups.
+func isValidRepositoryNamespace(namespace string) bool {
+	for _, slug := range strings.Split(namespace, "/") {
+		if !isValidRepositorySlug(slug) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidCodeCommitRepository(repository string) bool {
+	return len(repository) <= maxCodeCommitRepositoryLength && isValidRepositorySlug(repository)
+}
+
+func isValidAWSRegion(region string) bool {
+	return awsRegionRegexp.MatchString(region)
+}
+
+// isValidSparsePath accepts a directory for git sparse-checkout in cone mode, which is a
+// slash-separated path relative to the root of the repository without glob characters.
+func isValidSparsePath(sparsePath string) bool {
+	if sparsePath == "" || strings.ContainsAny(sparsePath, "*?[\\\n") {
+		return false
+	}
+	for _, segment := range strings.Split(sparsePath, "/") {
+		if segment == "" || segment == "." || segment == ".." || segment == ".git" {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidSubmodulePath accepts the same paths as isValidSparsePath, as submodule paths are also passed to git as literal paths.
+func isValidSubmodulePath(submodulePath string) bool {
+	return isValidSparsePath(submodulePath)
+}
+
+func isValidCloneFilter(cloneFilter string) bool {
+	return cloneFilterRegexp.MatchString(cloneFilter)
+}
+
+// isValidLFSPattern accepts a pattern for git lfs pull --include or --exclude, which joins the
+// patterns of each flag with commas.
+func isValidLFSPattern(lfsPattern string) bool {
+	return lfsPattern != "" && !strings.ContainsAny(lfsPattern, ",\n")
+}
+
+// isValidRef accepts a branch or tag name, or a fully qualified ref such as refs/pull/123/head,
+// which follow the same rules as a branch name after refs/.
+func isValidRef(ref string) bool {
+	return isValidBranchName(strings.TrimPrefix(ref, "refs/"))
+}
@@ -0,0 +1,228 @@
+package scm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsValidCommitID(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		commitID string
+		expected bool
+	}{
+		{testBananaCommitID, true},
+		{strings.ToUpper(testBananaCommitID), true},
+		{"d1f47a7", true},
+		{"d1f4", true},
+		{strings.Repeat("ab", 32), true},
+		{"d1f", false},
+		{strings.Repeat("ab", 32) + "a", false},
+		{"master", false},
+		{"d1f47a7c9c618dd338cf1ef9ba83639005b0212g", false},
+		{"HEAD~1", false},
+		{"", false},
+	} {
+		if actual := isValidCommitID(tc.commitID); actual != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.commitID, tc.expected, actual)
+		}
+	}
+}
+
+func TestIsValidChangesetID(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		changesetID string
+		expected    bool
+	}{
+		{testHgGitChangesetID, true},
+		{"4538981d2c3f", true},
+		{testHgGitChangesetID + "0", false},
+		{"tip", false},
+		{"42", false},
+		{"", false},
+	} {
+		if actual := isValidChangesetID(tc.changesetID); actual != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.changesetID, tc.expected, actual)
+		}
+	}
+}
+
+func TestIsValidBranchName(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		branch   string
+		expected bool
+	}{
+		{"master", true},
+		{"feature/split", true},
+		{"release-1.0", true},
+		{"a@b", true},
+		{"a/-b", true},
+		{"ü", true},
+		{"", false},
+		{"HEAD", false},
+		{"@", false},
+		{"-x", false},
+		{"a..b", false},
+		{"a/.b", false},
+		{".x", false},
+		{"x.lock", false},
+		{"a.lock/b", false},
+		{"a b", false},
+		{"a\tb", false},
+		{"a~", false},
+		{"a^", false},
+		{"a:", false},
+		{"a?", false},
+		{"a*", false},
+		{"a[", false},
+		{"a\\b", false},
+		{"/a", false},
+		{"a/", false},
+		{"a//b", false},
+		{"a.", false},
+		{"a@{b", false},
+	} {
+		if actual := isValidBranchName(tc.branch); actual != tc.expected {
+			t.Errorf("%q: expected %v, got %v", tc.branch, tc.expected, actual)
+		}
+	}
+}
+
+func TestIsValidHost(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		host     string
+		expected bool
+	}{
+		{"github.com", true},
+		{"git.example.com:9418", true},
+		{"localhost", true},
+		{"127.0.0.1", true},
+		{"127.0.0.1:22", true},
+		{"[::1]", true},
+		{"[::1]:22", true},
+		{"xn--bcher-kva.example", true},
+		{"", false},
+		{"git.example.com:", false},
+		{"git.example.com:99999", false},
+		{"-git.example.com", false},
+		{"git..example.com", false},
+		{"git_example.com", false},
+		{"git.example.com/path", false},
+		{"user@git.example.com", false},
+		{strings.Repeat("a", 64) + ".com", false},
+	} {
+		if actual := isValidHost(tc.host); actual != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.host, tc.expected, actual)
+		}
+	}
+}
+
+func TestIsValidRepositorySlug(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		slug     string
+		expected bool
+	}{
+		{"smartystreets_ruby", true},
+		{"peter-edge", true},
+		{"go.scm", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"peter-edge/smartystreets_ruby", false},
+		{"banana split", false},
+	} {
+		if actual := isValidRepositorySlug(tc.slug); actual != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.slug, tc.expected, actual)
+		}
+	}
+	for _, tc := range []struct {
+		namespace string
+		expected  bool
+	}{
+		{"codeship", true},
+		{"group/sub/subsub", true},
+		{"group//sub", false},
+		{"group/", false},
+		{"group/..", false},
+	} {
+		if actual := isValidRepositoryNamespace(tc.namespace); actual != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.namespace, tc.expected, actual)
+		}
+	}
+}
+
+func TestIsValidAWSRegion(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		region   string
+		expected bool
+	}{
+		{"us-east-1", true},
+		{"ap-southeast-2", true},
+		{"us-gov-west-1", true},
+		{"us-east", false},
+		{"US-EAST-1", false},
+		{"us-east-1.evil.com", false},
+		{"", false},
+	} {
+		if actual := isValidAWSRegion(tc.region); actual != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.region, tc.expected, actual)
+		}
+	}
+}
+
+func TestIsValidSparsePath(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		sparsePath string
+		expected   bool
+	}{
+		{"services", true},
+		{"services/api", true},
+		{"docs/user guide", true},
+		{".github", true},
+		{"", false},
+		{"/services", false},
+		{"services/", false},
+		{"services//api", false},
+		{"./services", false},
+		{"services/../api", false},
+		{".git", false},
+		{"services/*", false},
+		{"services/api?", false},
+		{"services/[a]", false},
+		{"services\\api", false},
+		{"services\napi", false},
+	} {
+		if actual := isValidSparsePath(tc.sparsePath); actual != tc.expected {
+			t.Errorf("%q: expected %v, got %v", tc.sparsePath, tc.expected, actual)
+		}
+	}
+}
+
+func TestIsValidCloneFilter(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		cloneFilter string
+		expected    bool
+	}{
+		{"blob:none", true},
+		{"tree:0", true},
+		{"blob:limit=1024", true},
+		{"blob:limit=1m", true},
+		{"blob:limit=", false},
+		{"blob:limit=1t", false},
+		{"tree:1", false},
+		{"sparse:oid=master:.gitfilter", false},
+		{"blob:none --upload-pack=touch", false},
+		{"", false},
+	} {
+		if actual := isValidCloneFilter(tc.cloneFilter); actual != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.cloneFilter, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package scm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HandlerOutputTar   = string(ExportFormatTar)
+	HandlerOutputTarGz = string(ExportFormatTarGz)
+	HandlerOutputZip   = string(ExportFormatZip)
+	HandlerOutputPath  = "path"
+
+	// HandlerRevisionHeader is the response header that holds the CheckoutResult Revision for archive output.
+	HandlerRevisionHeader = "X-Scm-Revision"
+
+	defaultHandlerMaxConcurrentCheckouts = 4
+	defaultHandlerTimeout                = 10 * time.Minute
+	defaultHandlerMaxRequestBytes        = 1 << 20
+)
+
+var (
+	workspaceIDRegexp = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+	handlerOutputContentTypes = map[string]string{
+		HandlerOutputTar:   "application/x-tar",
+		HandlerOutputTarGz: "application/gzip",
+		HandlerOutputZip:   "application/zip",
+	}
+)
+
+// HandlerOptions are the options for NewHandler.
+type HandlerOptions struct {
+	// WorkspaceDirPath is the directory that checkouts are made in. It must exist.
+	WorkspaceDirPath string
+	// MaxConcurrentCheckouts is the number of checkouts that can run at once, 4 if not set.
+	// Requests that cannot start a checkout before their timeout fail with 503.
+	MaxConcurrentCheckouts int
+	// Timeout is the time a request can take to start and finish its checkout, 10 minutes if not set.
+	// A checkout cannot be interrupted, so one that times out keeps its slot until it finishes,
+	// and its workspace is then removed. git aborts HTTP transfers that stall, and ssh with a
+	// private key gives up on hosts that stop answering, so that a hung fetch eventually frees
+	// its slot. The archive is streamed after the checkout, and is not bounded by Timeout.
+	Timeout time.Duration
+	// MaxRequestBytes is the largest request body accepted, 1 MiB if not set.
+	MaxRequestBytes int64
+	// AllowedTypes are the CheckoutOptionsTypes that can be checked out. If not set, every
+	// type except local is allowed, as local checkouts read from the filesystem of the server.
+	AllowedTypes []CheckoutOptionsType
+}
+
+// HandlerWorkspace is the response for path output.
+type HandlerWorkspace struct {
+	ID       string `json:"id"`
+	Path     string `json:"path"`
+	Revision string `json:"revision"`
+}
+
+// HandlerError is the response for a failed request.
+type HandlerError struct {
+	Error            string                    `json:"error"`
+	ValidationErrors []*HandlerValidationError `json:"validation_errors,omitempty"`
+}
+
+type HandlerValidationError struct {
+	Type      ValidationErrorType `json:"type"`
+	FieldPath string              `json:"field_path"`
+	Message   string              `json:"message"`
+}
+
+// NewHandler returns a http.Handler that performs checkouts on request.
+//
+//	POST /checkout?output=tar|tar.gz|zip|path[&include_vcs_metadata=true]
+//	DELETE /workspaces/<id>
+//
+// The body of POST /checkout is an ExternalCheckoutOptions document of any version that
+// ReadExternalCheckoutOptions accepts. For tar, tar.gz and zip output, the default being tar.gz,
+// the checkout is streamed back as an archive written like Export and removed, with the revision
+// in HandlerRevisionHeader. VCS metadata is only included with include_vcs_metadata=true, as the
+// remote URL in .git/config can hold the credentials of the checkout.
+// For path output, a HandlerWorkspace is returned and the checkout is kept until it is deleted.
+//
+// Failed requests return a HandlerError with status 400 for malformed requests, 422 for
+// invalid checkout options, 403 for types that are not allowed, 503 if the checkout could not
+// be started, 504 if it timed out, and 502 if it failed.
+func NewHandler(handlerOptions *HandlerOptions) (http.Handler, error) {
+	return newHandler(handlerOptions)
+}
+
+// ***** PRIVATE *****
+
+type handler struct {
+	workspaceDirPath string
+	timeout          time.Duration
+	maxRequestBytes  int64
+	allowedTypes     map[CheckoutOptionsType]bool
+	semaphore        chan struct{}
+	checkout         func(CheckoutOptions, string) (*CheckoutResult, error)
+}
+
+type handlerCheckoutResult struct {
+	checkoutResult *CheckoutResult
+	err            error
+}
+
+func newHandler(handlerOptions *HandlerOptions) (*handler, error) {
+	if handlerOptions.WorkspaceDirPath == "" {
+		return nil, fmt.Errorf("scm: HandlerOptions.WorkspaceDirPath not set")
+	}
+	workspaceDirPath, err := filepath.Abs(handlerOptions.WorkspaceDirPath)
+	if err != nil {
+		return nil, err
+	}
+	fileInfo, err := os.Stat(workspaceDirPath)
+	if err != nil {
+		return nil, err
+	}
+	if !fileInfo.IsDir() {
+		return nil, fmt.Errorf("scm: HandlerOptions.WorkspaceDirPath not a directory: %v", workspaceDirPath)
+	}
+	maxConcurrentCheckouts := handlerOptions.MaxConcurrentCheckouts
+	if maxConcurrentCheckouts <= 0 {
+		maxConcurrentCheckouts = defaultHandlerMaxConcurrentCheckouts
+	}
+	timeout := handlerOptions.Timeout
+	if timeout <= 0 {
+		timeout = defaultHandlerTimeout
+	}
+	maxRequestBytes := handlerOptions.MaxRequestBytes
+	if maxRequestBytes <= 0 {
+		maxRequestBytes = defaultHandlerMaxRequestBytes
+	}
+	allowedTypes := make(map[CheckoutOptionsType]bool)
+	if len(handlerOptions.AllowedTypes) == 0 {
+		for _, checkoutOptionsType := range AllCheckoutOptionsTypes() {
+			allowedTypes[checkoutOptionsType] = checkoutOptionsType != CheckoutOptionsTypeLocal
+		}
+	} else {
+		for _, checkoutOptionsType := range handlerOptions.AllowedTypes {
+			allowedTypes[checkoutOptionsType] = true
+		}
+	}
+	return &handler{
+		workspaceDirPath: workspaceDirPath,
+		timeout:          timeout,
+		maxRequestBytes:  maxRequestBytes,
+		allowedTypes:     allowedTypes,
+		semaphore:        make(chan struct{}, maxConcurrentCheckouts),
+		checkout:         checkout,
+	}, nil
+}
+
+func (h *handler) ServeHTTP(responseWriter http.ResponseWriter, request *http.Request) {
+	switch {
+	case request.URL.Path == "/checkout":
+		if request.Method != http.MethodPost {
+			responseWriter.Header().Set("Allow", http.MethodPost)
+			writeHandlerError(responseWriter, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", request.Method))
+			return
+		}
+		h.serveCheckout(responseWriter, request)
+	case strings.HasPrefix(request.URL.Path, "/workspaces/"):
+		if request.Method != http.MethodDelete {
+			responseWriter.Header().Set("Allow", http.MethodDelete)
+			writeHandlerError(responseWriter, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", request.Method))
+			return
+		}
+		h.serveDeleteWorkspace(responseWriter, strings.TrimPrefix(request.URL.Path, "/workspaces/"))
+	default:
+		writeHandlerError(responseWriter, http.StatusNotFound, fmt.Errorf("%s not found", request.URL.Path))
+	}
+}
+
+func (h *handler) serveCheckout(responseWriter http.ResponseWriter, request *http.Request) {
+	output := request.URL.Query().Get("output")
+	switch output {
+	case "":
+		output = HandlerOutputTarGz
+	case HandlerOutputTar, HandlerOutputTarGz, HandlerOutputZip, HandlerOutputPath:
+	default:
+		writeHandlerError(responseWriter, http.StatusBadRequest, fmt.Errorf("unknown output %q", output))
+		return
+	}
+	includeVCSMetadata := false
+	if value := request.URL.Query().Get("include_vcs_metadata"); value != "" {
+		var err error
+		if includeVCSMetadata, err = strconv.ParseBool(value); err != nil {
+			writeHandlerError(responseWriter, http.StatusBadRequest, fmt.Errorf("invalid include_vcs_metadata %q", value))
+			return
+		}
+	}
+	checkoutOptions, err := h.readCheckoutOptions(request)
+	if err != nil {
+		if _, ok := err.(ValidationErrors); ok {
+			writeHandlerError(responseWriter, http.StatusUnprocessableEntity, err)
+			return
+		}
+		writeHandlerError(responseWriter, http.StatusBadRequest, err)
+		return
+	}
+	if !h.allowedTypes[checkoutOptions.Type()] {
+		writeHandlerError(responseWriter, http.StatusForbidden, fmt.Errorf("type %s not allowed", checkoutOptions.Type().String()))
+		return
+	}
+	ctx, cancel := context.WithTimeout(request.Context(), h.timeout)
+	defer cancel()
+	select {
+	case h.semaphore <- struct{}{}:
+	case <-ctx.Done():
+		writeHandlerError(responseWriter, http.StatusServiceUnavailable, fmt.Errorf("could not start checkout: %v", ctx.Err()))
+		return
+	}
+	workspaceID, err := newWorkspaceID()
+	if err != nil {
+		<-h.semaphore
+		writeHandlerError(responseWriter, http.StatusInternalServerError, err)
+		return
+	}
+	workspacePath := filepath.Join(h.workspaceDirPath, workspaceID)
+	done := make(chan *handlerCheckoutResult, 1)
+	go func() {
+		defer func() { <-h.semaphore }()
+		checkoutResult, err := h.checkout(checkoutOptions, workspacePath)
+		done <- &handlerCheckoutResult{checkoutResult, err}
+	}()
+	var result *handlerCheckoutResult
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		go func() {
+			<-done
+			_ = os.RemoveAll(workspacePath)
+		}()
+		writeHandlerError(responseWriter, http.StatusGatewayTimeout, fmt.Errorf("checkout did not finish: %v", ctx.Err()))
+		return
+	}
+	if result.err != nil {
+		_ = os.RemoveAll(workspacePath)
+		writeHandlerError(responseWriter, http.StatusBadGateway, result.err)
+		return
+	}
+	if output == HandlerOutputPath {
+		writeHandlerJSON(
+			responseWriter,
+			http.StatusOK,
+			&HandlerWorkspace{
+				ID:       workspaceID,
+				Path:     workspacePath,
+				Revision: result.checkoutResult.Revision,
+			},
+		)
+		return
+	}
+	defer func() { _ = os.RemoveAll(workspacePath) }()
+	responseWriter.Header().Set(HandlerRevisionHeader, result.checkoutResult.Revision)
+	responseWriter.Header().Set("Content-Type", handlerOutputContentTypes[output])
+	// the status is already sent, so errors can only abort the stream
+	_ = writeExport(workspacePath, responseWriter, ExportFormat(output), includeVCSMetadata)
+}
+
+func (h *handler) serveDeleteWorkspace(responseWriter http.ResponseWriter, workspaceID string) {
+	if !workspaceIDRegexp.MatchString(workspaceID) {
+		writeHandlerError(responseWriter, http.StatusNotFound, fmt.Errorf("workspace %s not found", workspaceID))
+		return
+	}
+	workspacePath := filepath.Join(h.workspaceDirPath, workspaceID)
+	if _, err := os.Stat(workspacePath); err != nil {
+		writeHandlerError(responseWriter, http.StatusNotFound, fmt.Errorf("workspace %s not found", workspaceID))
+		return
+	}
+	if err := os.RemoveAll(workspacePath); err != nil {
+		writeHandlerError(responseWriter, http.StatusInternalServerError, err)
+		return
+	}
+	responseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) readCheckoutOptions(request *http.Request) (CheckoutOptions, error) {
+	data, err := ioutil.ReadAll(http.MaxBytesReader(nil, request.Body, h.maxRequestBytes))
+	if err != nil {
+		return nil, err
+	}
+	externalCheckoutOptionsV2, err := readExternalCheckoutOptions(
+		func(value interface{}) error {
+			return json.Unmarshal(data, value)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertExternalCheckoutOptionsV2(externalCheckoutOptionsV2)
+}
+
+func newWorkspaceID() (string, error) {
+	data := make([]byte, 16)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+func writeHandlerError(responseWriter http.ResponseWriter, statusCode int, err error) {
+	handlerError := &HandlerError{
+		Error: err.Error(),
+	}
+	if validationErrors, ok := err.(ValidationErrors); ok {
+		for _, validationError := range validationErrors {
+			handlerError.ValidationErrors = append(
+				handlerError.ValidationErrors,
+				&HandlerValidationError{
+					Type:      validationError.Type(),
+					FieldPath: validationError.FieldPath(),
+					Message:   validationError.Error(),
+				},
+			)
+		}
+	}
+	writeHandlerJSON(responseWriter, statusCode, handlerError)
+}
+
+func writeHandlerJSON(responseWriter http.ResponseWriter, statusCode int, value interface{}) {
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(statusCode)
+	_ = json.NewEncoder(responseWriter).Encode(value)
+}
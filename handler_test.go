@@ -0,0 +1,250 @@
+package scm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlerCheckout(t *testing.T) {
+	t.Parallel()
+	workDir, commitID := newTestGitRepository(t)
+	workspaceDirPath := getTempDir(t)
+	server := newTestHandlerServer(t, &HandlerOptions{WorkspaceDirPath: workspaceDirPath, AllowedTypes: []CheckoutOptionsType{CheckoutOptionsTypeLocal}})
+	defer server.Close()
+	body := fmt.Sprintf(`{"type": "local", "path": %q, "branch": "master", "commit_id": %q}`, workDir, commitID)
+
+	for _, output := range []string{"", HandlerOutputTar, HandlerOutputTarGz} {
+		response := postTestHandler(t, server, output, body)
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", output, response.StatusCode)
+		}
+		if revision := response.Header.Get(HandlerRevisionHeader); revision != commitID {
+			t.Errorf("%s: expected revision %s, got %s", output, commitID, revision)
+		}
+		var reader io.Reader = response.Body
+		if output != HandlerOutputTar {
+			gzipReader, err := gzip.NewReader(response.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader = gzipReader
+		}
+		files := readTestTar(t, reader)
+		response.Body.Close()
+		if files["README.md"] != "banana\n" {
+			t.Errorf("%s: expected README.md in %v", output, files)
+		}
+		if _, ok := files[".git/config"]; ok {
+			t.Errorf("%s: expected no VCS metadata in %v", output, files)
+		}
+	}
+	response := postTestHandler(t, server, HandlerOutputTar+"&include_vcs_metadata=true", body)
+	files := readTestTar(t, response.Body)
+	response.Body.Close()
+	if _, ok := files[".git/config"]; !ok {
+		t.Errorf("expected .git/config with include_vcs_metadata=true in %v", files)
+	}
+
+	response = postTestHandler(t, server, HandlerOutputPath, body)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", response.StatusCode)
+	}
+	handlerWorkspace := &HandlerWorkspace{}
+	if err := json.NewDecoder(response.Body).Decode(handlerWorkspace); err != nil {
+		t.Fatal(err)
+	}
+	if handlerWorkspace.Revision != commitID {
+		t.Errorf("expected revision %s, got %s", commitID, handlerWorkspace.Revision)
+	}
+	testLocalCheckout(t, handlerWorkspace.Path, commitID)
+	if statusCode := deleteTestHandlerWorkspace(t, server, handlerWorkspace.ID); statusCode != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", statusCode)
+	}
+	if statusCode := deleteTestHandlerWorkspace(t, server, handlerWorkspace.ID); statusCode != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", statusCode)
+	}
+	checkTestWorkspaceDirEmpty(t, workspaceDirPath)
+}
+
+func TestHandlerErrors(t *testing.T) {
+	t.Parallel()
+	server := newTestHandlerServer(t, &HandlerOptions{WorkspaceDirPath: getTempDir(t)})
+	defer server.Close()
+	for _, testCase := range []struct {
+		output             string
+		body               string
+		expectedStatusCode int
+		expectedFieldPaths []string
+	}{
+		{"", `{"type": "github"`, http.StatusBadRequest, nil},
+		{"", `{"apiVersion": "v3", "type": "github"}`, http.StatusBadRequest, nil},
+		{"rar", testGithubHandlerBody, http.StatusBadRequest, nil},
+		{"tar&include_vcs_metadata=maybe", testGithubHandlerBody, http.StatusBadRequest, nil},
+		{"", `{"type": "github", "user": "peter-edge", "commit_id": "master"}`, http.StatusUnprocessableEntity, []string{"Repository", "Branch", "CommitID"}},
+		{"", `{"type": "local", "path": "/etc", "branch": "master", "commit_id": "a40e854"}`, http.StatusForbidden, nil},
+	} {
+		response := postTestHandler(t, server, testCase.output, testCase.body)
+		handlerError := &HandlerError{}
+		if err := json.NewDecoder(response.Body).Decode(handlerError); err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if response.StatusCode != testCase.expectedStatusCode {
+			t.Errorf("%s: expected %d, got %d: %s", testCase.body, testCase.expectedStatusCode, response.StatusCode, handlerError.Error)
+		}
+		var fieldPaths []string
+		for _, validationError := range handlerError.ValidationErrors {
+			fieldPaths = append(fieldPaths, validationError.FieldPath)
+		}
+		if strings.Join(fieldPaths, ",") != strings.Join(testCase.expectedFieldPaths, ",") {
+			t.Errorf("%s: expected field paths %v, got %v", testCase.body, testCase.expectedFieldPaths, fieldPaths)
+		}
+	}
+	response, err := http.Get(server.URL + "/checkout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", response.StatusCode)
+	}
+	if statusCode := deleteTestHandlerWorkspace(t, server, "../banana"); statusCode != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", statusCode)
+	}
+}
+
+func TestHandlerConcurrencyAndTimeout(t *testing.T) {
+	t.Parallel()
+	workspaceDirPath := getTempDir(t)
+	handler, err := newHandler(
+		&HandlerOptions{
+			WorkspaceDirPath:       workspaceDirPath,
+			MaxConcurrentCheckouts: 1,
+			Timeout:                100 * time.Millisecond,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	handler.checkout = func(checkoutOptions CheckoutOptions, path string) (*CheckoutResult, error) {
+		defer close(finished)
+		if err := os.Mkdir(path, 0755); err != nil {
+			return nil, err
+		}
+		close(started)
+		<-release
+		return &CheckoutResult{Revision: "a40e854"}, nil
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	statusCodes := make(chan int)
+	go func() {
+		response := postTestHandler(t, server, "", testGithubHandlerBody)
+		response.Body.Close()
+		statusCodes <- response.StatusCode
+	}()
+	<-started
+	response := postTestHandler(t, server, "", testGithubHandlerBody)
+	response.Body.Close()
+	if response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 while the checkout is running, got %d", response.StatusCode)
+	}
+	if statusCode := <-statusCodes; statusCode != http.StatusGatewayTimeout {
+		t.Errorf("expected 504 for the checkout, got %d", statusCode)
+	}
+	close(release)
+	<-finished
+	// the workspace of the timed out checkout is removed once the checkout finishes
+	for i := 0; i < 100; i++ {
+		fileInfos, err := ioutil.ReadDir(workspaceDirPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(fileInfos) == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	checkTestWorkspaceDirEmpty(t, workspaceDirPath)
+}
+
+const testGithubHandlerBody = `{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854"}`
+
+func newTestHandlerServer(t *testing.T, handlerOptions *HandlerOptions) *httptest.Server {
+	handler, err := NewHandler(handlerOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return httptest.NewServer(handler)
+}
+
+func postTestHandler(t *testing.T, server *httptest.Server, output string, body string) *http.Response {
+	url := server.URL + "/checkout"
+	if output != "" {
+		url += "?output=" + output
+	}
+	response, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response
+}
+
+func deleteTestHandlerWorkspace(t *testing.T, server *httptest.Server, workspaceID string) int {
+	request, err := http.NewRequest(http.MethodDelete, server.URL+"/workspaces/"+workspaceID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	return response.StatusCode
+}
+
+func readTestTar(t *testing.T, reader io.Reader) map[string]string {
+	files := make(map[string]string)
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buffer bytes.Buffer
+		if _, err := io.Copy(&buffer, tarReader); err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = buffer.String()
+	}
+}
+
+func checkTestWorkspaceDirEmpty(t *testing.T, workspaceDirPath string) {
+	fileInfos, err := ioutil.ReadDir(workspaceDirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fileInfo := range fileInfos {
+		t.Errorf("expected %s to be removed", filepath.Join(workspaceDirPath, fileInfo.Name()))
+	}
+}
@@ -0,0 +1,152 @@
+package scm
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/codeship/go-exec"
+)
+
+// pullGitLFS fetches the Git LFS objects of the checkout at path and replaces their pointer files.
+// git-lfs reads the URL and credentials of the origin remote, so it uses the same ones as the clone,
+// and env has the GIT_SSH_COMMAND of the clone if it used SSH.
+func pullGitLFS(executor exec.Executor, env []string, lfsOptions *LFSOptions, path string) error {
+	var versionStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"git", "lfs", "version"},
+			SubDir: path,
+			Stderr: &versionStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return fmt.Errorf("GitLFSNotInstalled: git-lfs must be installed to fetch LFS objects: %v %v", err.Error(), versionStderr.String())
+	}
+	var installStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"git", "lfs", "install", "--local"},
+			SubDir: path,
+			Stderr: &installStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return fmt.Errorf("CouldNotInstallLFS: %v %v", err.Error(), installStderr.String())
+	}
+	args := []string{"git", "lfs", "pull"}
+	if len(lfsOptions.Include) > 0 {
+		args = append(args, "--include="+strings.Join(lfsOptions.Include, ","))
+	}
+	if len(lfsOptions.Exclude) > 0 {
+		args = append(args, "--exclude="+strings.Join(lfsOptions.Exclude, ","))
+	}
+	var pullStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   args,
+			Env:    env,
+			SubDir: path,
+			Stderr: &pullStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return fmt.Errorf("CouldNotPullLFS: %v %v", err.Error(), pullStderr.String())
+	}
+	return nil
+}
+
+// getGitLFSObjects returns the number of LFS objects checked out at path and their size in bytes.
+// git lfs ls-files --long lists each LFS file with the full object ID and * if it was checked out
+// or - if it is still a pointer file, and the size is read from the pointer files in HEAD, as git
+// lfs ls-files only reports sizes rounded for display.
+func getGitLFSObjects(executor exec.Executor, path string) (int, int64, error) {
+	var lsFilesStdout bytes.Buffer
+	var lsFilesStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"git", "lfs", "ls-files", "--long"},
+			SubDir: path,
+			Stdout: &lsFilesStdout,
+			Stderr: &lsFilesStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return 0, 0, fmt.Errorf("CouldNotListLFSFiles: %v %v", err.Error(), lsFilesStderr.String())
+	}
+	// files with the same content share an object, so only one pointer file is read for each
+	objectIDs := make(map[string]bool)
+	var objectNames []string
+	for _, line := range strings.Split(strings.TrimRight(lsFilesStdout.String(), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		split := strings.SplitN(line, " ", 3)
+		if len(split) != 3 {
+			return 0, 0, fmt.Errorf("CouldNotListLFSFiles: %v", line)
+		}
+		if split[1] != "*" || objectIDs[split[0]] {
+			continue
+		}
+		objectIDs[split[0]] = true
+		objectNames = append(objectNames, "HEAD:"+split[2])
+	}
+	if len(objectNames) == 0 {
+		return 0, 0, nil
+	}
+	var catFileStdout bytes.Buffer
+	var catFileStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"git", "cat-file", "--batch"},
+			SubDir: path,
+			Stdin:  strings.NewReader(strings.Join(objectNames, "\n") + "\n"),
+			Stdout: &catFileStdout,
+			Stderr: &catFileStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return 0, 0, fmt.Errorf("CouldNotReadLFSPointers: %v %v", err.Error(), catFileStderr.String())
+	}
+	var lfsObjectsSize int64
+	reader := bufio.NewReader(&catFileStdout)
+	for _, objectName := range objectNames {
+		size, err := readGitLFSPointerSize(reader)
+		if err != nil {
+			return 0, 0, fmt.Errorf("CouldNotReadLFSPointers: %v %v", objectName, err.Error())
+		}
+		lfsObjectsSize += size
+	}
+	return len(objectNames), lfsObjectsSize, nil
+}
+
+// readGitLFSPointerSize reads the next object from the output of git cat-file --batch, which is a
+// header line with the object ID, type and size followed by the content and a newline, and returns
+// the size recorded in the content as a Git LFS pointer file.
+func readGitLFSPointerSize(reader *bufio.Reader) (int64, error) {
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	split := strings.Fields(header)
+	if len(split) != 3 {
+		return 0, fmt.Errorf("unexpected header %q", strings.TrimSpace(header))
+	}
+	contentSize, err := strconv.Atoi(split[2])
+	if err != nil {
+		return 0, err
+	}
+	content := make([]byte, contentSize+1)
+	if _, err := io.ReadFull(reader, content); err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "size ") {
+			return strconv.ParseInt(strings.TrimPrefix(line, "size "), 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("no size in %s", split[0])
+}
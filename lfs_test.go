@@ -0,0 +1,384 @@
+package scm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codeship/go-exec"
+)
+
+func TestLocalLFS(t *testing.T) {
+	// not parallel since the git config is isolated in the environment
+	setTestGitLFSEnv(t)
+	server := newTestGitLFSServer(t)
+	defer server.Close()
+	workDir, _ := newTestGitRepository(t)
+	files := map[string]string{
+		"assets/logo.png":      "logo",
+		"assets/logo-copy.png": "logo",
+		"data/model.bin":       "model weights",
+	}
+	for path, content := range files {
+		writeTestGitLFSFile(t, workDir, path, content)
+		server.objects[getTestGitLFSObjectID(content)] = content
+	}
+	writeTestGitLFSConfig(t, workDir, server.URL)
+	runTestCommand(t, workDir, "git", "add", ".")
+	runTestCommand(t, workDir, "git", "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "lfs")
+	commitID := strings.TrimSpace(runTestCommand(t, workDir, "git", "rev-parse", "HEAD"))
+	for _, testCase := range []struct {
+		lfsOptions *LFSOptions
+		// expected are the paths of the files that are expected to be fetched
+		expected               []string
+		expectedLFSObjects     int
+		expectedLFSObjectsSize int64
+	}{
+		{
+			lfsOptions: nil,
+		},
+		{
+			lfsOptions:             &LFSOptions{},
+			expected:               []string{"assets/logo.png", "assets/logo-copy.png", "data/model.bin"},
+			expectedLFSObjects:     2,
+			expectedLFSObjectsSize: int64(len("logo") + len("model weights")),
+		},
+		{
+			lfsOptions:             &LFSOptions{Include: []string{"assets"}},
+			expected:               []string{"assets/logo.png", "assets/logo-copy.png"},
+			expectedLFSObjects:     1,
+			expectedLFSObjectsSize: int64(len("logo")),
+		},
+		{
+			lfsOptions:             &LFSOptions{Include: []string{"assets", "data"}, Exclude: []string{"*.png"}},
+			expected:               []string{"data/model.bin"},
+			expectedLFSObjects:     1,
+			expectedLFSObjectsSize: int64(len("model weights")),
+		},
+	} {
+		server.reset()
+		tempDir := getTempDir(t)
+		checkoutResult, err := Checkout(
+			&LocalCheckoutOptions{
+				Path:     workDir,
+				Branch:   "master",
+				CommitID: commitID,
+				LFS:      testCase.lfsOptions,
+			},
+			tempDir,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testLocalCheckout(t, tempDir, commitID)
+		if checkoutResult.LFSObjects != testCase.expectedLFSObjects || checkoutResult.LFSObjectsSize != testCase.expectedLFSObjectsSize {
+			t.Errorf("%+v: expected %d LFS objects of %d bytes, got %d of %d", testCase.lfsOptions, testCase.expectedLFSObjects, testCase.expectedLFSObjectsSize, checkoutResult.LFSObjects, checkoutResult.LFSObjectsSize)
+		}
+		if downloads := server.getDownloads(); len(downloads) != testCase.expectedLFSObjects {
+			t.Errorf("%+v: expected %d LFS objects to be downloaded, got %v", testCase.lfsOptions, testCase.expectedLFSObjects, downloads)
+		}
+		expected := make(map[string]bool)
+		for _, path := range testCase.expected {
+			expected[path] = true
+		}
+		for path, content := range files {
+			data, err := ioutil.ReadFile(filepath.Join(tempDir, path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fetched := string(data) == content; fetched != expected[path] {
+				t.Errorf("%+v: %s: expected fetched to be %v, got %q", testCase.lfsOptions, path, expected[path], string(data))
+			}
+		}
+		installed := runTestGitConfig(t, tempDir, "filter.lfs.process") != ""
+		if installed != (testCase.lfsOptions != nil) {
+			t.Errorf("%+v: expected git lfs install to be run to be %v", testCase.lfsOptions, testCase.lfsOptions != nil)
+		}
+	}
+}
+
+func TestGitLFSSSHCommand(t *testing.T) {
+	// not parallel since the git config is isolated in the environment
+	setTestGitLFSEnv(t)
+	server := newTestGitLFSServer(t)
+	defer server.Close()
+	server.authorization = "Bearer banana"
+	workDir, _ := newTestGitRepository(t)
+	writeTestGitLFSFile(t, workDir, "data/model.bin", "model weights")
+	server.objects[getTestGitLFSObjectID("model weights")] = "model weights"
+	// git-lfs runs git-lfs-authenticate over SSH for an ssh:// LFS URL, and uses the href and header it prints
+	writeTestGitLFSConfig(t, workDir, "ssh://git@lfs.example.invalid/banana.git")
+	runTestCommand(t, workDir, "git", "add", ".")
+	runTestCommand(t, workDir, "git", "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "lfs")
+	commitID := strings.TrimSpace(runTestCommand(t, workDir, "git", "rev-parse", "HEAD"))
+	sshDir := getTempDir(t)
+	sshCommandPath := filepath.Join(sshDir, "lfs-ssh")
+	sshLogPath := filepath.Join(sshDir, "log")
+	if err := ioutil.WriteFile(
+		sshCommandPath,
+		[]byte(joinStrings(
+			"#!/bin/sh\n",
+			"echo \"$@\" >> ", sshLogPath, "\n",
+			"case \"$*\" in\n",
+			"*git-lfs-authenticate*) echo '{\"href\": \"", server.URL, "\", \"header\": {\"Authorization\": \"", server.authorization, "\"}}' ;;\n",
+			"*) echo \"unsupported command: $*\" >&2; exit 1 ;;\n",
+			"esac\n",
+		)),
+		0755,
+	); err != nil {
+		t.Fatal(err)
+	}
+	tempDir := getTempDir(t)
+	executor, err := exec.NewOsExecutor(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a local clone does not use SSH, so only git-lfs runs the command
+	checkoutResult, err := checkoutGitWithExecutor(executor, sshCommandPath, nil, workDir, "master", "", commitID, nil, "", nil, &LFSOptions{}, clonePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkoutResult.LFSObjects != 1 || len(server.getDownloads()) != 1 {
+		t.Errorf("expected one LFS object to be downloaded, got %d and %v", checkoutResult.LFSObjects, server.getDownloads())
+	}
+	data, err := ioutil.ReadFile(sshLogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "git-lfs-authenticate") {
+		t.Errorf("expected git lfs pull to be run with the SSH command of the clone, got %q", string(data))
+	}
+}
+
+func TestGitLFSNotInstalled(t *testing.T) {
+	// not parallel since PATH is changed
+	workDir, commitID := newTestGitRepository(t)
+	gitPath, err := osexec.LookPath("git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only git is on PATH, so that a git-lfs installed on the system is not found
+	binDir := getTempDir(t)
+	if err := os.Symlink(gitPath, filepath.Join(binDir, "git")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir)
+	_, err = Checkout(
+		&LocalCheckoutOptions{
+			Path:     workDir,
+			Branch:   "master",
+			CommitID: commitID,
+			LFS:      &LFSOptions{},
+		},
+		filepath.Join(getTempDir(t), "checkout"),
+	)
+	if err == nil || !strings.HasPrefix(err.Error(), "GitLFSNotInstalled: ") {
+		t.Errorf("expected GitLFSNotInstalled, got %v", err)
+	}
+}
+
+func TestLFSOptionsValidation(t *testing.T) {
+	t.Parallel()
+	err := Validate(
+		&GitCheckoutOptions{
+			User:     "git",
+			Host:     "example.com",
+			Path:     "/banana.git",
+			Branch:   "master",
+			CommitID: testBananaCommitID,
+			LFS:      &LFSOptions{Include: []string{"assets", "a,b"}, Exclude: []string{""}},
+		},
+	)
+	validationErrors, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	expected := ValidationErrors{
+		newValidationErrorInvalidFieldFormat("*GitCheckoutOptions", "a,b", "LFS", "Include"),
+		newValidationErrorInvalidFieldFormat("*GitCheckoutOptions", "", "LFS", "Exclude"),
+	}
+	if len(validationErrors) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, validationErrors)
+	}
+	for i := range expected {
+		if validationErrors[i].Error() != expected[i].Error() {
+			t.Errorf("expected %v, got %v", expected[i], validationErrors[i])
+		}
+	}
+}
+
+// writeTestGitLFSFile writes a pointer file for content at path in workDir.
+func writeTestGitLFSFile(t *testing.T, workDir string, path string, content string) {
+	filePath := filepath.Join(workDir, path)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	pointer := joinStrings(
+		"version https://git-lfs.github.com/spec/v1\n",
+		"oid sha256:", getTestGitLFSObjectID(content), "\n",
+		"size ", strconv.Itoa(len(content)), "\n",
+	)
+	if err := ioutil.WriteFile(filePath, []byte(pointer), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeTestGitLFSConfig writes the .gitattributes that track the test LFS files, and the
+// .lfsconfig that points git-lfs at url, to workDir.
+func writeTestGitLFSConfig(t *testing.T, workDir string, url string) {
+	for name, content := range map[string]string{
+		".gitattributes": "*.png filter=lfs diff=lfs merge=lfs -text\n*.bin filter=lfs diff=lfs merge=lfs -text\n",
+		".lfsconfig":     joinStrings("[lfs]\n\turl = ", url, "\n"),
+	} {
+		if err := ioutil.WriteFile(filepath.Join(workDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func getTestGitLFSObjectID(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// setTestGitLFSEnv skips the test if git-lfs is not installed, and isolates git from the user and
+// system config, so that a git-lfs installed for the user does not fetch objects on clone.
+func setTestGitLFSEnv(t *testing.T) {
+	if _, err := osexec.LookPath("git-lfs"); err != nil {
+		t.Skip("git-lfs not installed")
+	}
+	t.Setenv("HOME", getTempDir(t))
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_TERMINAL_PROMPT", "0")
+}
+
+// testGitLFSServer is a Git LFS server that implements the batch API for downloads of objects,
+// which are served from objects by object ID, and records the object IDs that are downloaded.
+// If authorization is set, requests must have it as their Authorization header.
+type testGitLFSServer struct {
+	*httptest.Server
+	objects       map[string]string
+	authorization string
+	lock          sync.Mutex
+	downloads     []string
+}
+
+type testGitLFSBatchObject struct {
+	OID     string                            `json:"oid"`
+	Size    int64                             `json:"size"`
+	Actions map[string]*testGitLFSBatchAction `json:"actions,omitempty"`
+	Error   *testGitLFSBatchError             `json:"error,omitempty"`
+}
+
+type testGitLFSBatchAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+type testGitLFSBatchError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func newTestGitLFSServer(t *testing.T) *testGitLFSServer {
+	server := &testGitLFSServer{
+		objects: make(map[string]string),
+	}
+	server.Server = httptest.NewServer(server)
+	return server
+}
+
+func (s *testGitLFSServer) ServeHTTP(responseWriter http.ResponseWriter, request *http.Request) {
+	if s.authorization != "" && request.Header.Get("Authorization") != s.authorization {
+		http.Error(responseWriter, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case request.Method == http.MethodPost && request.URL.Path == "/objects/batch":
+		var batchRequest struct {
+			Operation string                   `json:"operation"`
+			Objects   []*testGitLFSBatchObject `json:"objects"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&batchRequest); err != nil || batchRequest.Operation != "download" {
+			http.Error(responseWriter, "only downloads are supported", http.StatusUnprocessableEntity)
+			return
+		}
+		var objects []*testGitLFSBatchObject
+		for _, object := range batchRequest.Objects {
+			batchObject := &testGitLFSBatchObject{
+				OID:  object.OID,
+				Size: object.Size,
+			}
+			if _, ok := s.objects[object.OID]; ok {
+				downloadAction := &testGitLFSBatchAction{
+					Href: joinStrings(s.URL, "/download/", object.OID),
+				}
+				if s.authorization != "" {
+					downloadAction.Header = map[string]string{"Authorization": s.authorization}
+				}
+				batchObject.Actions = map[string]*testGitLFSBatchAction{"download": downloadAction}
+			} else {
+				batchObject.Error = &testGitLFSBatchError{Code: http.StatusNotFound, Message: "object not found"}
+			}
+			objects = append(objects, batchObject)
+		}
+		responseWriter.Header().Set("Content-Type", "application/vnd.git-lfs+json")
+		_ = json.NewEncoder(responseWriter).Encode(
+			map[string]interface{}{
+				"transfer": "basic",
+				"objects":  objects,
+			},
+		)
+	case request.Method == http.MethodGet && strings.HasPrefix(request.URL.Path, "/download/"):
+		oid := strings.TrimPrefix(request.URL.Path, "/download/")
+		content, ok := s.objects[oid]
+		if !ok {
+			http.NotFound(responseWriter, request)
+			return
+		}
+		s.lock.Lock()
+		s.downloads = append(s.downloads, oid)
+		s.lock.Unlock()
+		_, _ = io.WriteString(responseWriter, content)
+	default:
+		http.NotFound(responseWriter, request)
+	}
+}
+
+func (s *testGitLFSServer) getDownloads() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string{}, s.downloads...)
+}
+
+func (s *testGitLFSServer) reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.downloads = nil
+}
+
+// runTestGitConfig returns the value of name in the local git config of dirPath, or "" if it is not set.
+func runTestGitConfig(t *testing.T, dirPath string, name string) string {
+	cmd := osexec.Command("git", "config", "--local", "--get", name)
+	cmd.Dir = dirPath
+	output, err := cmd.Output()
+	if err != nil {
+		if _, ok := err.(*osexec.ExitError); ok {
+			return ""
+		}
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(output))
+}
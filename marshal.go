@@ -0,0 +1,82 @@
+package scm
+
+import (
+	"encoding/json"
+)
+
+// CheckoutOptionsJSON wraps a CheckoutOptions so it can be a field of a struct that
+// is marshalled to and from JSON. Marshalling produces an ExternalCheckoutOptions document,
+// and unmarshalling accepts any document ReadExternalCheckoutOptions accepts.
+// A nil CheckoutOptions is marshalled as null.
+type CheckoutOptionsJSON struct {
+	CheckoutOptions CheckoutOptions
+}
+
+func (c CheckoutOptionsJSON) MarshalJSON() ([]byte, error) {
+	externalCheckoutOptions, err := getMarshalExternalCheckoutOptions(c.CheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(externalCheckoutOptions)
+}
+
+func (c *CheckoutOptionsJSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		c.CheckoutOptions = nil
+		return nil
+	}
+	checkoutOptions, err := unmarshalCheckoutOptions(
+		func(value interface{}) error {
+			return json.Unmarshal(data, value)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	c.CheckoutOptions = checkoutOptions
+	return nil
+}
+
+// CheckoutOptionsYAML is the YAML equivalent of CheckoutOptionsJSON. It implements
+// the Marshaler and Unmarshaler interfaces of gopkg.in/yaml.v2, which gopkg.in/yaml.v3
+// also supports.
+type CheckoutOptionsYAML struct {
+	CheckoutOptions CheckoutOptions
+}
+
+func (c CheckoutOptionsYAML) MarshalYAML() (interface{}, error) {
+	externalCheckoutOptions, err := getMarshalExternalCheckoutOptions(c.CheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
+	if externalCheckoutOptions == nil {
+		return nil, nil
+	}
+	return externalCheckoutOptions, nil
+}
+
+func (c *CheckoutOptionsYAML) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	checkoutOptions, err := unmarshalCheckoutOptions(unmarshal)
+	if err != nil {
+		return err
+	}
+	c.CheckoutOptions = checkoutOptions
+	return nil
+}
+
+// ***** PRIVATE *****
+
+func getMarshalExternalCheckoutOptions(checkoutOptions CheckoutOptions) (*ExternalCheckoutOptions, error) {
+	if checkoutOptions == nil {
+		return nil, nil
+	}
+	return convertCheckoutOptions(checkoutOptions)
+}
+
+func unmarshalCheckoutOptions(unmarshal func(interface{}) error) (CheckoutOptions, error) {
+	externalCheckoutOptionsV2, err := readExternalCheckoutOptions(unmarshal)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertExternalCheckoutOptionsV2(externalCheckoutOptionsV2)
+}
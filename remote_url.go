@@ -0,0 +1,648 @@
+package scm
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	remoteURLProtocolSSH   = "ssh"
+	remoteURLProtocolHTTPS = "https"
+	remoteURLProtocolGit   = "git"
+)
+
+var (
+	// user@host:path, where host cannot contain a slash so that local paths with colons are not matched
+	scpLikeRemoteURLRegexp    = regexp.MustCompile(`^(?:([^@/]+)@)?([^:/]+):(.*)$`)
+	codeCommitHostRegexp      = regexp.MustCompile(`^git-codecommit\.([a-z0-9-]+)\.amazonaws\.com$`)
+	codeCommitRemoteURLRegexp = regexp.MustCompile(`^codecommit::([a-z0-9-]+)://(?:[^@/]+@)?([^/]+)$`)
+	visualStudioHostRegexp    = regexp.MustCompile(`^([^.]+)\.visualstudio\.com$`)
+)
+
+// ParseRemoteURL returns the CheckoutOptions for a clone URL as it would be given to git clone or hg clone.
+//
+// scp-like (git@github.com:org/repo.git), ssh://, git://, http(s):// and file:// URLs are recognized.
+// GitHub, GitLab, Bitbucket, Azure DevOps and CodeCommit URLs produce the provider specific options,
+// ssh URLs for the user hg produce HgCheckoutOptions, file URLs and local paths produce LocalCheckoutOptions,
+// SSH and git:// URLs on other hosts produce GitCheckoutOptions, and http(s):// URLs on other hosts produce
+// GiteaCheckoutOptions, which clone anonymously over HTTPS and so need an owner/repository path.
+// SSH URLs set SSHSecurityOptions without a private key, and http(s):// URLs set no SecurityOptions, so
+// they are cloned anonymously over HTTPS. Credentials in URLs are not kept, and ssh:// URLs with a port
+// on other hosts are invalid, as GitCheckoutOptions cannot carry the port.
+// Branch, CommitID and ChangesetID are never set.
+func ParseRemoteURL(remoteURL string) (CheckoutOptions, error) {
+	return parseRemoteURL(remoteURL)
+}
+
+// parseRemoteURLForType parses remoteURL as a URL for checkoutOptionsType, which resolves URLs that
+// parseRemoteURL would give another type, such as a Gitea URL on any host or a git URL on github.com.
+// Types that are only recognized by their hosts are parsed with parseRemoteURL.
+func parseRemoteURLForType(remoteURL string, checkoutOptionsType string) (CheckoutOptions, error) {
+	switch checkoutOptionsType {
+	case CheckoutOptionsTypeGitea.String(), CheckoutOptionsTypeGit.String(), CheckoutOptionsTypeHg.String():
+	default:
+		return parseRemoteURL(remoteURL)
+	}
+	remoteURL = strings.TrimSpace(remoteURL)
+	if remoteURL == "" {
+		return nil, newErrorInvalidRemoteURL(remoteURL)
+	}
+	parsed, err := splitRemoteURL(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+	if checkoutOptionsType == CheckoutOptionsTypeGitea.String() {
+		return parseGiteaRemoteURL(parsed)
+	}
+	// GitCheckoutOptions and HgCheckoutOptions cannot clone over HTTPS
+	if parsed.protocol == remoteURLProtocolHTTPS {
+		return nil, newErrorInvalidRemoteURL(remoteURL)
+	}
+	return parseGenericRemoteURL(parsed)
+}
+
+type parsedRemoteURL struct {
+	raw      string
+	protocol string
+	user     string
+	host     string
+	// path is the path after the host, without a leading slash
+	path string
+	// scpLike is true if the path was separated from the host by a colon
+	scpLike bool
+}
+
+func parseRemoteURL(remoteURL string) (CheckoutOptions, error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if remoteURL == "" {
+		return nil, newErrorInvalidRemoteURL(remoteURL)
+	}
+	if matches := codeCommitRemoteURLRegexp.FindStringSubmatch(remoteURL); matches != nil {
+		return &CodeCommitCheckoutOptions{
+			Region:     matches[1],
+			Repository: matches[2],
+		}, nil
+	}
+	if strings.HasPrefix(remoteURL, "file://") || strings.HasPrefix(remoteURL, "/") || strings.HasPrefix(remoteURL, ".") {
+		return &LocalCheckoutOptions{
+			Path: remoteURL,
+		}, nil
+	}
+	parsed, err := splitRemoteURL(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+	host := strings.ToLower(parsed.host)
+	switch {
+	case host == "github.com":
+		return parseGithubRemoteURL(parsed)
+	case host == "gitlab.com":
+		return parseGitlabRemoteURL(parsed)
+	case host == "bitbucket.org":
+		return parseBitbucketRemoteURL(parsed)
+	case host == "dev.azure.com", host == "ssh.dev.azure.com", host == "vs-ssh.visualstudio.com", visualStudioHostRegexp.MatchString(host):
+		return parseAzureDevOpsRemoteURL(parsed)
+	case codeCommitHostRegexp.MatchString(host):
+		return parseCodeCommitRemoteURL(parsed)
+	default:
+		return parseGenericRemoteURL(parsed)
+	}
+}
+
+func splitRemoteURL(remoteURL string) (*parsedRemoteURL, error) {
+	if !strings.Contains(remoteURL, "://") {
+		matches := scpLikeRemoteURLRegexp.FindStringSubmatch(remoteURL)
+		if matches == nil || matches[3] == "" {
+			return nil, newErrorInvalidRemoteURL(remoteURL)
+		}
+		return &parsedRemoteURL{
+			raw:      remoteURL,
+			protocol: remoteURLProtocolSSH,
+			user:     matches[1],
+			host:     matches[2],
+			path:     strings.TrimPrefix(matches[3], "/"),
+			scpLike:  true,
+		}, nil
+	}
+	u, err := url.Parse(remoteURL)
+	if err != nil || u.Host == "" {
+		return nil, newErrorInvalidRemoteURL(remoteURL)
+	}
+	parsed := &parsedRemoteURL{
+		raw:  remoteURL,
+		host: u.Host,
+		path: strings.TrimPrefix(u.EscapedPath(), "/"),
+	}
+	if u.User != nil {
+		parsed.user = u.User.Username()
+	}
+	switch u.Scheme {
+	case "ssh", "git+ssh", "ssh+git":
+		parsed.protocol = remoteURLProtocolSSH
+	case "https", "http":
+		parsed.protocol = remoteURLProtocolHTTPS
+	case "git":
+		parsed.protocol = remoteURLProtocolGit
+	default:
+		return nil, newErrorInvalidRemoteURL(remoteURL)
+	}
+	if parsed.path == "" {
+		return nil, newErrorInvalidRemoteURL(remoteURL)
+	}
+	return parsed, nil
+}
+
+func parseGithubRemoteURL(parsed *parsedRemoteURL) (CheckoutOptions, error) {
+	segments := getRemoteURLPathSegments(parsed)
+	if len(segments) != 2 {
+		return nil, newErrorInvalidRemoteURL(parsed.raw)
+	}
+	return &GithubCheckoutOptions{
+		User:            segments[0],
+		Repository:      segments[1],
+		SecurityOptions: getRemoteURLSecurityOptions(parsed),
+	}, nil
+}
+
+// GitLab repositories can be nested in subgroups, which all go in User.
+func parseGitlabRemoteURL(parsed *parsedRemoteURL) (CheckoutOptions, error) {
+	segments := getRemoteURLPathSegments(parsed)
+	if len(segments) < 2 {
+		return nil, newErrorInvalidRemoteURL(parsed.raw)
+	}
+	return &GitlabCheckoutOptions{
+		User:            strings.Join(segments[0:len(segments)-1], "/"),
+		Repository:      segments[len(segments)-1],
+		SecurityOptions: getRemoteURLSecurityOptions(parsed),
+	}, nil
+}
+
+// Bitbucket serves Mercurial repositories to the SSH user hg, and git repositories otherwise.
+func parseBitbucketRemoteURL(parsed *parsedRemoteURL) (CheckoutOptions, error) {
+	segments := getRemoteURLPathSegments(parsed)
+	if len(segments) != 2 {
+		return nil, newErrorInvalidRemoteURL(parsed.raw)
+	}
+	if parsed.protocol == remoteURLProtocolSSH && parsed.user == "hg" {
+		return &BitbucketHgCheckoutOptions{
+			User:            segments[0],
+			Repository:      segments[1],
+			SecurityOptions: getRemoteURLSecurityOptions(parsed),
+		}, nil
+	}
+	return &BitbucketGitCheckoutOptions{
+		User:            segments[0],
+		Repository:      segments[1],
+		SecurityOptions: getRemoteURLSecurityOptions(parsed),
+	}, nil
+}
+
+// Azure DevOps URLs are one of:
+//
+//	git@ssh.dev.azure.com:v3/org/project/repo
+//	org@vs-ssh.visualstudio.com:v3/org/project/repo
+//	https://org@dev.azure.com/org/project/_git/repo
+//	https://org.visualstudio.com/[DefaultCollection/]project/_git/repo
+func parseAzureDevOpsRemoteURL(parsed *parsedRemoteURL) (CheckoutOptions, error) {
+	segments := getRemoteURLPathSegments(parsed)
+	var organization, project, repository string
+	switch {
+	case parsed.protocol == remoteURLProtocolSSH:
+		if len(segments) != 4 || segments[0] != "v3" {
+			return nil, newErrorInvalidRemoteURL(parsed.raw)
+		}
+		organization, project, repository = segments[1], segments[2], segments[3]
+	case strings.ToLower(parsed.host) == "dev.azure.com":
+		if len(segments) != 4 || segments[2] != "_git" {
+			return nil, newErrorInvalidRemoteURL(parsed.raw)
+		}
+		organization, project, repository = segments[0], segments[1], segments[3]
+	default:
+		if len(segments) == 4 && segments[0] == "DefaultCollection" {
+			segments = segments[1:]
+		}
+		if len(segments) != 3 || segments[1] != "_git" {
+			return nil, newErrorInvalidRemoteURL(parsed.raw)
+		}
+		organization = visualStudioHostRegexp.FindStringSubmatch(strings.ToLower(parsed.host))[1]
+		project, repository = segments[0], segments[2]
+	}
+	var err error
+	if organization, err = url.PathUnescape(organization); err != nil {
+		return nil, newErrorInvalidRemoteURL(parsed.raw)
+	}
+	if project, err = url.PathUnescape(project); err != nil {
+		return nil, newErrorInvalidRemoteURL(parsed.raw)
+	}
+	if repository, err = url.PathUnescape(repository); err != nil {
+		return nil, newErrorInvalidRemoteURL(parsed.raw)
+	}
+	return &AzureDevOpsCheckoutOptions{
+		Organization:    organization,
+		Project:         project,
+		Repository:      repository,
+		SecurityOptions: getRemoteURLSecurityOptions(parsed),
+	}, nil
+}
+
+// CodeCommit SSH users are the SSH key ID.
+func parseCodeCommitRemoteURL(parsed *parsedRemoteURL) (CheckoutOptions, error) {
+	segments := strings.Split(strings.Trim(parsed.path, "/"), "/")
+	if len(segments) != 3 || segments[0] != "v1" || segments[1] != "repos" || segments[2] == "" {
+		return nil, newErrorInvalidRemoteURL(parsed.raw)
+	}
+	codeCommitCheckoutOptions := &CodeCommitCheckoutOptions{
+		Region:          codeCommitHostRegexp.FindStringSubmatch(strings.ToLower(parsed.host))[1],
+		Repository:      segments[2],
+		SecurityOptions: getRemoteURLSecurityOptions(parsed),
+	}
+	if parsed.protocol == remoteURLProtocolSSH {
+		codeCommitCheckoutOptions.SSHKeyID = parsed.user
+	}
+	return codeCommitCheckoutOptions, nil
+}
+
+// Gitea can be on any host, and does not run a git daemon. Its SSH URLs are built in the
+// scp-like form, which cannot carry a port.
+func parseGiteaRemoteURL(parsed *parsedRemoteURL) (CheckoutOptions, error) {
+	segments := getRemoteURLPathSegments(parsed)
+	if len(segments) != 2 || parsed.protocol == remoteURLProtocolGit {
+		return nil, newErrorInvalidRemoteURL(parsed.raw)
+	}
+	if parsed.protocol == remoteURLProtocolSSH && strings.Contains(parsed.host, ":") {
+		return nil, newErrorInvalidRemoteURL(parsed.raw)
+	}
+	return &GiteaCheckoutOptions{
+		User:            segments[0],
+		Host:            strings.ToLower(parsed.host),
+		Repository:      segments[1],
+		SecurityOptions: getRemoteURLSecurityOptions(parsed),
+	}, nil
+}
+
+// GitCheckoutOptions only builds git:// and scp-like SSH URLs, so ssh:// URLs are rewritten
+// to the equivalent scp-like form, which cannot carry a port. http(s):// URLs are cloned over
+// HTTPS with GiteaCheckoutOptions rather than over git://.
+func parseGenericRemoteURL(parsed *parsedRemoteURL) (CheckoutOptions, error) {
+	if parsed.protocol == remoteURLProtocolHTTPS {
+		return parseGiteaRemoteURL(parsed)
+	}
+	user := parsed.user
+	if user == "" {
+		user = "git"
+	}
+	if parsed.protocol == remoteURLProtocolGit {
+		return &GitCheckoutOptions{
+			User: user,
+			Host: parsed.host,
+			Path: joinStrings("/", parsed.path),
+		}, nil
+	}
+	if strings.Contains(parsed.host, ":") {
+		return nil, newErrorInvalidRemoteURL(parsed.raw)
+	}
+	if user == "hg" && !parsed.scpLike {
+		return &HgCheckoutOptions{
+			User:            user,
+			Host:            parsed.host,
+			Path:            joinStrings("/", parsed.path),
+			SecurityOptions: getRemoteURLSecurityOptions(parsed),
+		}, nil
+	}
+	path := joinStrings(":", parsed.path)
+	if !parsed.scpLike {
+		path = joinStrings(":/", parsed.path)
+	}
+	return &GitCheckoutOptions{
+		User:            user,
+		Host:            parsed.host,
+		Path:            path,
+		SecurityOptions: getRemoteURLSecurityOptions(parsed),
+	}, nil
+}
+
+func getRemoteURLPathSegments(parsed *parsedRemoteURL) []string {
+	path := strings.TrimSuffix(strings.Trim(parsed.path, "/"), ".git")
+	segments := strings.Split(path, "/")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil
+		}
+	}
+	return segments
+}
+
+func getRemoteURLSecurityOptions(parsed *parsedRemoteURL) SecurityOptions {
+	if parsed.protocol == remoteURLProtocolSSH {
+		return &SSHSecurityOptions{}
+	}
+	return nil
+}
+
+func newErrorInvalidRemoteURL(remoteURL string) error {
+	return fmt.Errorf("scm: InvalidRemoteURL: %v", remoteURL)
+}
+
+const (
+	redactedCredential = "REDACTED"
+)
+
+type CheckoutRemoteURLs struct {
+	// CloneURL is the URL that Checkout clones from for the configured SecurityOptions, with credentials redacted.
+	CloneURL string
+	// HTTPSCloneURL is the anonymous HTTPS clone URL, if the repository can be served over HTTPS.
+	HTTPSCloneURL string
+	// SSHCloneURL is the SSH clone URL, if the repository can be served over SSH.
+	SSHCloneURL string
+	// WebURL, BranchWebURL and CommitWebURL are the provider web pages for the repository,
+	// the branch, and the commit or changeset. They are empty if the provider has no web interface
+	// or the branch or commit is not set.
+	WebURL       string
+	BranchWebURL string
+	CommitWebURL string
+}
+
+// RemoteURLs returns the clone and web URLs for the repository described by checkoutOptions.
+func RemoteURLs(checkoutOptions CheckoutOptions) (*CheckoutRemoteURLs, error) {
+	return getRemoteURLs(checkoutOptions)
+}
+
+func getRemoteURLs(checkoutOptions CheckoutOptions) (*CheckoutRemoteURLs, error) {
+	var remoteURLs *CheckoutRemoteURLs
+	if err := CheckoutOptionsSwitch(
+		checkoutOptions,
+		func(gitCheckoutOptions *GitCheckoutOptions) error {
+			redacted := *gitCheckoutOptions
+			redacted.SecurityOptions = getRedactedSecurityOptions(gitCheckoutOptions.SecurityOptions)
+			cloneURL, err := getGitURL(&redacted)
+			if err != nil {
+				return err
+			}
+			sshPath := gitCheckoutOptions.Path
+			if !strings.HasPrefix(sshPath, ":") {
+				sshPath = joinStrings(":", strings.TrimPrefix(sshPath, "/"))
+			}
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL:      cloneURL,
+				HTTPSCloneURL: getHTTPSURL(gitCheckoutOptions.Host, joinStrings("/", strings.TrimLeft(gitCheckoutOptions.Path, ":/"))),
+				SSHCloneURL:   getSSHURL("", gitCheckoutOptions.User, gitCheckoutOptions.Host, sshPath),
+			}
+			return nil
+		},
+		func(githubCheckoutOptions *GithubCheckoutOptions) error {
+			redacted := *githubCheckoutOptions
+			redacted.SecurityOptions = getRedactedSecurityOptions(githubCheckoutOptions.SecurityOptions)
+			cloneURL, err := getGithubURL(&redacted)
+			if err != nil {
+				return err
+			}
+			repositoryPath := joinStrings(githubCheckoutOptions.User, "/", githubCheckoutOptions.Repository)
+			webURL := getHTTPSURL("github.com", joinStrings("/", repositoryPath))
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL:      cloneURL,
+				HTTPSCloneURL: joinStrings(webURL, ".git"),
+				SSHCloneURL:   getSSHURL("", "git", "github.com", joinStrings(":", repositoryPath, ".git")),
+				WebURL:        webURL,
+				BranchWebURL:  getWebURL(webURL, "/tree/", githubCheckoutOptions.Branch),
+				CommitWebURL:  getWebURL(webURL, "/commit/", githubCheckoutOptions.CommitID),
+			}
+			return nil
+		},
+		func(hgCheckoutOptions *HgCheckoutOptions) error {
+			cloneURL, err := getHgURL(hgCheckoutOptions)
+			if err != nil {
+				return err
+			}
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL:      cloneURL,
+				HTTPSCloneURL: getHTTPSURL(hgCheckoutOptions.Host, hgCheckoutOptions.Path),
+				SSHCloneURL:   getSSHURL("ssh://", hgCheckoutOptions.User, hgCheckoutOptions.Host, hgCheckoutOptions.Path),
+			}
+			return nil
+		},
+		func(bitbucketGitCheckoutOptions *BitbucketGitCheckoutOptions) error {
+			cloneURL, err := getBitbucketGitURL(bitbucketGitCheckoutOptions)
+			if err != nil {
+				return err
+			}
+			repositoryPath := joinStrings(bitbucketGitCheckoutOptions.User, "/", bitbucketGitCheckoutOptions.Repository)
+			webURL := getHTTPSURL("bitbucket.org", joinStrings("/", repositoryPath))
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL:      cloneURL,
+				HTTPSCloneURL: joinStrings(webURL, ".git"),
+				SSHCloneURL:   getSSHURL("", "git", "bitbucket.org", joinStrings(":", repositoryPath, ".git")),
+				WebURL:        webURL,
+				BranchWebURL:  getWebURL(webURL, "/src/", bitbucketGitCheckoutOptions.Branch),
+				CommitWebURL:  getWebURL(webURL, "/commits/", bitbucketGitCheckoutOptions.CommitID),
+			}
+			return nil
+		},
+		func(bitbucketHgCheckoutOptions *BitbucketHgCheckoutOptions) error {
+			cloneURL, err := getBitbucketHgURL(bitbucketHgCheckoutOptions)
+			if err != nil {
+				return err
+			}
+			repositoryPath := joinStrings(bitbucketHgCheckoutOptions.User, "/", bitbucketHgCheckoutOptions.Repository)
+			webURL := getHTTPSURL("bitbucket.org", joinStrings("/", repositoryPath))
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL:      cloneURL,
+				HTTPSCloneURL: webURL,
+				SSHCloneURL:   getSSHURL("ssh://", "hg", "bitbucket.org", joinStrings("/", repositoryPath)),
+				WebURL:        webURL,
+				CommitWebURL:  getWebURL(webURL, "/commits/", bitbucketHgCheckoutOptions.ChangesetID),
+			}
+			return nil
+		},
+		func(gitlabCheckoutOptions *GitlabCheckoutOptions) error {
+			cloneURL, err := getGitlabURL(gitlabCheckoutOptions)
+			if err != nil {
+				return err
+			}
+			repositoryPath := joinStrings(gitlabCheckoutOptions.User, "/", gitlabCheckoutOptions.Repository)
+			webURL := getHTTPSURL("gitlab.com", joinStrings("/", repositoryPath))
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL:      cloneURL,
+				HTTPSCloneURL: joinStrings(webURL, ".git"),
+				SSHCloneURL:   getSSHURL("", "git", "gitlab.com", joinStrings(":", repositoryPath, ".git")),
+				WebURL:        webURL,
+				BranchWebURL:  getWebURL(webURL, "/-/tree/", gitlabCheckoutOptions.Branch),
+				CommitWebURL:  getWebURL(webURL, "/-/commit/", gitlabCheckoutOptions.CommitID),
+			}
+			return nil
+		},
+		func(giteaCheckoutOptions *GiteaCheckoutOptions) error {
+			redacted := *giteaCheckoutOptions
+			redacted.SecurityOptions = getRedactedSecurityOptions(giteaCheckoutOptions.SecurityOptions)
+			cloneURL, err := getGiteaURL(&redacted)
+			if err != nil {
+				return err
+			}
+			repositoryPath := joinStrings(giteaCheckoutOptions.User, "/", giteaCheckoutOptions.Repository)
+			webURL := getHTTPSURL(giteaCheckoutOptions.Host, joinStrings("/", repositoryPath))
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL:      cloneURL,
+				HTTPSCloneURL: joinStrings(webURL, ".git"),
+				SSHCloneURL:   getSSHURL("", "git", giteaCheckoutOptions.Host, joinStrings(":", repositoryPath, ".git")),
+				WebURL:        webURL,
+				BranchWebURL:  getWebURL(webURL, "/src/branch/", giteaCheckoutOptions.Branch),
+				CommitWebURL:  getWebURL(webURL, "/commit/", giteaCheckoutOptions.CommitID),
+			}
+			return nil
+		},
+		func(azureDevOpsCheckoutOptions *AzureDevOpsCheckoutOptions) error {
+			redacted := *azureDevOpsCheckoutOptions
+			redacted.SecurityOptions = getRedactedSecurityOptions(azureDevOpsCheckoutOptions.SecurityOptions)
+			cloneURL, err := getAzureDevOpsURL(&redacted)
+			if err != nil {
+				return err
+			}
+			anonymous := *azureDevOpsCheckoutOptions
+			anonymous.SecurityOptions = nil
+			webURL, err := getAzureDevOpsURL(&anonymous)
+			if err != nil {
+				return err
+			}
+			anonymous.SecurityOptions = &SSHSecurityOptions{}
+			sshCloneURL, err := getAzureDevOpsURL(&anonymous)
+			if err != nil {
+				return err
+			}
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL:      cloneURL,
+				HTTPSCloneURL: webURL,
+				SSHCloneURL:   sshCloneURL,
+				WebURL:        webURL,
+				BranchWebURL:  getWebURL(webURL, "?version=GB", url.QueryEscape(azureDevOpsCheckoutOptions.Branch)),
+				CommitWebURL:  getWebURL(webURL, "/commit/", azureDevOpsCheckoutOptions.CommitID),
+			}
+			return nil
+		},
+		func(codeCommitCheckoutOptions *CodeCommitCheckoutOptions) error {
+			host := getCodeCommitHost(codeCommitCheckoutOptions.Region)
+			path := getCodeCommitPath(codeCommitCheckoutOptions.Repository)
+			cloneURL, err := getCodeCommitURL(codeCommitCheckoutOptions, time.Now())
+			if err != nil {
+				return err
+			}
+			if codeCommitCheckoutOptions.SecurityOptions != nil && codeCommitCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeAwsAccessKey {
+				cloneURL = getBasicAuthURL(
+					(codeCommitCheckoutOptions.SecurityOptions.(*AWSAccessKeySecurityOptions)).AccessKeyID,
+					redactedCredential,
+					host,
+					path,
+				)
+			}
+			sshCloneURL := joinStrings("ssh://", host, path)
+			if codeCommitCheckoutOptions.SSHKeyID != "" {
+				sshCloneURL = getSSHURL("ssh://", codeCommitCheckoutOptions.SSHKeyID, host, path)
+			}
+			webURL := joinStrings(
+				"https://", codeCommitCheckoutOptions.Region, ".console.aws.amazon.com/codesuite/codecommit/repositories/",
+				codeCommitCheckoutOptions.Repository,
+			)
+			webQuery := joinStrings("?region=", codeCommitCheckoutOptions.Region)
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL:      cloneURL,
+				HTTPSCloneURL: getHTTPSURL(host, path),
+				SSHCloneURL:   sshCloneURL,
+				WebURL:        joinStrings(webURL, "/browse", webQuery),
+				BranchWebURL:  getWebURL(webURL, "/browse/refs/heads/", codeCommitCheckoutOptions.Branch, webQuery),
+				CommitWebURL:  getWebURL(webURL, "/commit/", codeCommitCheckoutOptions.CommitID, webQuery),
+			}
+			return nil
+		},
+		func(svnCheckoutOptions *SvnCheckoutOptions) error {
+			cloneURL := redactRemoteURL(getSvnURL(svnCheckoutOptions.URL, svnCheckoutOptions.Path))
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL: cloneURL,
+			}
+			if strings.HasPrefix(cloneURL, "https://") || strings.HasPrefix(cloneURL, "http://") {
+				remoteURLs.HTTPSCloneURL = cloneURL
+			}
+			if strings.HasPrefix(cloneURL, "svn+ssh://") {
+				remoteURLs.SSHCloneURL = cloneURL
+			}
+			return nil
+		},
+		func(fossilCheckoutOptions *FossilCheckoutOptions) error {
+			cloneURL := redactRemoteURL(fossilCheckoutOptions.URL)
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL: cloneURL,
+			}
+			if strings.HasPrefix(cloneURL, "ssh://") {
+				remoteURLs.SSHCloneURL = cloneURL
+			}
+			// every Fossil repository served over HTTP is also its own web interface
+			if u, err := url.Parse(fossilCheckoutOptions.URL); err == nil && (u.Scheme == "https" || u.Scheme == "http") {
+				u.User = nil
+				webURL := strings.TrimSuffix(u.String(), "/")
+				remoteURLs.HTTPSCloneURL = webURL
+				remoteURLs.WebURL = webURL
+				remoteURLs.CommitWebURL = getWebURL(webURL, "/info/", fossilCheckoutOptions.CheckinID)
+			}
+			return nil
+		},
+		func(localCheckoutOptions *LocalCheckoutOptions) error {
+			remoteURLs = &CheckoutRemoteURLs{
+				CloneURL: localCheckoutOptions.Path,
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+	return remoteURLs, nil
+}
+
+// getRedactedSecurityOptions replaces the credentials in securityOptions that end up in a clone URL.
+func getRedactedSecurityOptions(securityOptions SecurityOptions) SecurityOptions {
+	if securityOptions == nil {
+		return nil
+	}
+	if securityOptions.Type() == SecurityOptionsTypeAccessToken {
+		return &AccessTokenSecurityOptions{
+			AccessToken: redactedCredential,
+		}
+	}
+	return securityOptions
+}
+
+// redactRemoteURL replaces any password in the user information of remoteURL.
+func redactRemoteURL(remoteURL string) string {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		// the userinfo may be what could not be parsed, so all of it is redacted
+		schemeIndex := strings.Index(remoteURL, "://")
+		if schemeIndex < 0 {
+			return remoteURL
+		}
+		authority := remoteURL[schemeIndex+3:]
+		if pathIndex := strings.Index(authority, "/"); pathIndex >= 0 {
+			authority = authority[:pathIndex]
+		}
+		userIndex := strings.LastIndex(authority, "@")
+		if userIndex < 0 {
+			return remoteURL
+		}
+		return joinStrings(remoteURL[:schemeIndex+3], redactedCredential, remoteURL[schemeIndex+3+userIndex:])
+	}
+	if u.User == nil {
+		return remoteURL
+	}
+	if _, ok := u.User.Password(); !ok {
+		return remoteURL
+	}
+	u.User = url.UserPassword(u.User.Username(), redactedCredential)
+	return u.String()
+}
+
+// getWebURL returns webURL with the given path elements appended, or the empty string if value is empty.
+func getWebURL(webURL string, prefix string, value string, suffix ...string) string {
+	if value == "" {
+		return ""
+	}
+	return joinStrings(append([]string{webURL, prefix, value}, suffix...)...)
+}
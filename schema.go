@@ -0,0 +1,298 @@
+package scm
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+const (
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	// jsonSchemaProbeValue is set on a field to find out whether validation allows it.
+	jsonSchemaProbeValue = "probe"
+)
+
+// ExternalCheckoutOptionsJSONSchema returns a JSON Schema (draft 2020-12) describing
+// ExternalCheckoutOptions as a union discriminated by type, and within each type by
+// security_options.type.
+//
+// The schema is derived by running ConvertExternalCheckoutOptions against probe values,
+// so the required, allowed and dependent fields always match validation. Commit ID,
+// changeset ID and region formats are expressed as patterns; branch names, hosts and
+// repository slugs are only checked by Validate.
+func ExternalCheckoutOptionsJSONSchema() ([]byte, error) {
+	return json.MarshalIndent(getExternalCheckoutOptionsJSONSchema(), "", "  ")
+}
+
+// ***** PRIVATE *****
+
+type jsonSchema map[string]interface{}
+
+func getExternalCheckoutOptionsJSONSchema() jsonSchema {
+	defs := make(jsonSchema)
+	var typeNames []string
+	var typeRefs []interface{}
+	for _, securityOptionsType := range AllSecurityOptionsTypes() {
+		defs[getSecurityOptionsJSONSchemaDefName(securityOptionsType)] = getExternalSecurityOptionsJSONSchema(securityOptionsType)
+	}
+	for _, checkoutOptionsType := range AllCheckoutOptionsTypes() {
+		defs[checkoutOptionsType.String()] = getExternalCheckoutOptionsTypeJSONSchema(checkoutOptionsType)
+		typeNames = append(typeNames, checkoutOptionsType.String())
+		typeRefs = append(typeRefs, jsonSchema{"$ref": joinStrings("#/$defs/", checkoutOptionsType.String())})
+	}
+	return jsonSchema{
+		"$schema":  jsonSchemaDialect,
+		"title":    "ExternalCheckoutOptions",
+		"type":     "object",
+		"required": []string{"type"},
+		"properties": jsonSchema{
+			"type": jsonSchema{"enum": typeNames},
+		},
+		"oneOf": typeRefs,
+		"$defs": defs,
+	}
+}
+
+// getExternalCheckoutOptionsTypeJSONSchema returns one branch without security_options,
+// and one branch for every SecurityOptionsType allowed for checkoutOptionsType.
+func getExternalCheckoutOptionsTypeJSONSchema(checkoutOptionsType CheckoutOptionsType) jsonSchema {
+	branches := []interface{}{getExternalCheckoutOptionsBranchJSONSchema(checkoutOptionsType, nil)}
+	for _, securityOptionsType := range AllSecurityOptionsTypes() {
+		securityOptionsType := securityOptionsType
+		if isSecurityOptionsTypeAllowed(checkoutOptionsType, securityOptionsType) {
+			branches = append(branches, getExternalCheckoutOptionsBranchJSONSchema(checkoutOptionsType, &securityOptionsType))
+		}
+	}
+	if len(branches) == 1 {
+		return branches[0].(jsonSchema)
+	}
+	return jsonSchema{"oneOf": branches}
+}
+
+func getExternalCheckoutOptionsBranchJSONSchema(checkoutOptionsType CheckoutOptionsType, securityOptionsType *SecurityOptionsType) jsonSchema {
+	newExternalCheckoutOptions := func() *ExternalCheckoutOptions {
+		externalCheckoutOptions := &ExternalCheckoutOptions{Type: checkoutOptionsType.String()}
+		if securityOptionsType != nil {
+			externalCheckoutOptions.SecurityOptions = &ExternalSecurityOptions{Type: securityOptionsType.String()}
+		}
+		return externalCheckoutOptions
+	}
+	var required []string
+	for _, fieldPath := range getProbeFieldPaths(newExternalCheckoutOptions(), ValidationErrorTypeRequiredFieldMissing) {
+		if !strings.HasPrefix(fieldPath, "SecurityOptions.") {
+			required = append(required, fieldPath)
+		}
+	}
+	properties := jsonSchema{"type": jsonSchema{"const": checkoutOptionsType.String()}}
+	dependentRequired := make(jsonSchema)
+	// alternatives are the fields that can be set instead of a required field, such as ref for branch
+	alternatives := make(map[string][]string)
+	externalType := reflect.TypeOf(ExternalCheckoutOptions{})
+	for i := 0; i < externalType.NumField(); i++ {
+		field := externalType.Field(i)
+		if field.Name == "Type" {
+			continue
+		}
+		if field.Name == "SecurityOptions" {
+			if securityOptionsType != nil {
+				properties[getJSONName(field)] = jsonSchema{"$ref": joinStrings("#/$defs/", getSecurityOptionsJSONSchemaDefName(*securityOptionsType))}
+			}
+			continue
+		}
+		externalCheckoutOptions := newExternalCheckoutOptions()
+		setProbeValue(reflect.ValueOf(externalCheckoutOptions).Elem().Field(i))
+		if containsString(getProbeFieldPaths(externalCheckoutOptions, ValidationErrorTypeFieldShouldNotBeSet), field.Name) {
+			continue
+		}
+		properties[getJSONName(field)] = getFieldJSONSchema(field, containsString(required, field.Name))
+		var dependencies []string
+		missingFieldPaths := getProbeFieldPaths(externalCheckoutOptions, ValidationErrorTypeRequiredFieldMissing)
+		for _, fieldPath := range missingFieldPaths {
+			if !containsString(required, fieldPath) && !strings.HasPrefix(fieldPath, "SecurityOptions.") {
+				dependencies = append(dependencies, getJSONNameByFieldName(externalType, fieldPath))
+			}
+		}
+		for _, fieldPath := range required {
+			if fieldPath != field.Name && !containsString(missingFieldPaths, fieldPath) {
+				alternatives[fieldPath] = append(alternatives[fieldPath], field.Name)
+			}
+		}
+		if len(dependencies) > 0 {
+			dependentRequired[getJSONName(field)] = dependencies
+		}
+	}
+	requiredJSONNames := []string{"type"}
+	// exactly one of a required field and its alternatives must be set, as validation also
+	// rejects setting more than one of them
+	var oneOfRequiredList []interface{}
+	for _, fieldPath := range required {
+		if len(alternatives[fieldPath]) == 0 {
+			requiredJSONNames = append(requiredJSONNames, getJSONNameByFieldName(externalType, fieldPath))
+			continue
+		}
+		var oneOfRequired []interface{}
+		for _, alternative := range append([]string{fieldPath}, alternatives[fieldPath]...) {
+			oneOfRequired = append(oneOfRequired, jsonSchema{"type": "object", "required": []string{getJSONNameByFieldName(externalType, alternative)}})
+		}
+		oneOfRequiredList = append(oneOfRequiredList, jsonSchema{"oneOf": oneOfRequired})
+	}
+	if securityOptionsType != nil {
+		requiredJSONNames = append(requiredJSONNames, "security_options")
+	}
+	schema := jsonSchema{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             requiredJSONNames,
+		"additionalProperties": false,
+	}
+	if len(dependentRequired) > 0 {
+		schema["dependentRequired"] = dependentRequired
+	}
+	switch len(oneOfRequiredList) {
+	case 0:
+	case 1:
+		schema["oneOf"] = oneOfRequiredList[0].(jsonSchema)["oneOf"]
+	default:
+		schema["allOf"] = oneOfRequiredList
+	}
+	return schema
+}
+
+func getExternalSecurityOptionsJSONSchema(securityOptionsType SecurityOptionsType) jsonSchema {
+	var checkoutOptionsType CheckoutOptionsType
+	for _, checkoutOptionsType = range AllCheckoutOptionsTypes() {
+		if isSecurityOptionsTypeAllowed(checkoutOptionsType, securityOptionsType) {
+			break
+		}
+	}
+	newExternalCheckoutOptions := func() *ExternalCheckoutOptions {
+		return &ExternalCheckoutOptions{
+			Type:            checkoutOptionsType.String(),
+			SecurityOptions: &ExternalSecurityOptions{Type: securityOptionsType.String()},
+		}
+	}
+	var required []string
+	for _, fieldPath := range getProbeFieldPaths(newExternalCheckoutOptions(), ValidationErrorTypeRequiredFieldMissing) {
+		if strings.HasPrefix(fieldPath, "SecurityOptions.") {
+			required = append(required, strings.TrimPrefix(fieldPath, "SecurityOptions."))
+		}
+	}
+	properties := jsonSchema{"type": jsonSchema{"const": securityOptionsType.String()}}
+	requiredJSONNames := []string{"type"}
+	externalType := reflect.TypeOf(ExternalSecurityOptions{})
+	for i := 0; i < externalType.NumField(); i++ {
+		field := externalType.Field(i)
+		if field.Name == "Type" {
+			continue
+		}
+		externalCheckoutOptions := newExternalCheckoutOptions()
+		setProbeValue(reflect.ValueOf(externalCheckoutOptions.SecurityOptions).Elem().Field(i))
+		if containsString(getProbeFieldPaths(externalCheckoutOptions, ValidationErrorTypeFieldShouldNotBeSet), joinStrings("SecurityOptions.", field.Name)) {
+			continue
+		}
+		isRequired := containsString(required, field.Name)
+		properties[getJSONName(field)] = getFieldJSONSchema(field, isRequired)
+		if isRequired {
+			requiredJSONNames = append(requiredJSONNames, getJSONName(field))
+		}
+	}
+	return jsonSchema{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             requiredJSONNames,
+		"additionalProperties": false,
+	}
+}
+
+func getFieldJSONSchema(field reflect.StructField, required bool) jsonSchema {
+	switch field.Type.Kind() {
+	case reflect.Bool:
+		return jsonSchema{"type": "boolean"}
+	case reflect.Slice:
+		return jsonSchema{"type": "array", "items": jsonSchema{"type": "string", "minLength": 1}}
+	case reflect.Int:
+		return jsonSchema{"type": "integer", "minimum": 0}
+	case reflect.Ptr:
+		// a block such as submodules, whose fields are all optional
+		properties := jsonSchema{}
+		for i := 0; i < field.Type.Elem().NumField(); i++ {
+			blockField := field.Type.Elem().Field(i)
+			properties[getJSONName(blockField)] = getFieldJSONSchema(blockField, false)
+		}
+		return jsonSchema{"type": "object", "properties": properties, "additionalProperties": false}
+	}
+	schema := jsonSchema{"type": "string"}
+	// an empty string is the same as an unset field once unmarshalled
+	if required {
+		schema["minLength"] = 1
+	}
+	switch field.Name {
+	case "CommitID":
+		schema["pattern"] = commitIDRegexp.String()
+	case "ChangesetID":
+		schema["pattern"] = changesetIDRegexp.String()
+	case "Region":
+		schema["pattern"] = awsRegionRegexp.String()
+	case "CloneFilter":
+		schema["pattern"] = cloneFilterRegexp.String()
+	}
+	return schema
+}
+
+func isSecurityOptionsTypeAllowed(checkoutOptionsType CheckoutOptionsType, securityOptionsType SecurityOptionsType) bool {
+	externalCheckoutOptions := &ExternalCheckoutOptions{
+		Type:            checkoutOptionsType.String(),
+		SecurityOptions: &ExternalSecurityOptions{Type: securityOptionsType.String()},
+	}
+	return !containsString(getProbeFieldPaths(externalCheckoutOptions, ValidationErrorTypeFieldShouldNotBeSet), "SecurityOptions") &&
+		len(getProbeFieldPaths(externalCheckoutOptions, ValidationErrorTypeSecurityNotImplementedForCheckoutOptionsType)) == 0
+}
+
+// getProbeFieldPaths returns the field paths of the validation errors of validationErrorType
+// reported when converting externalCheckoutOptions.
+func getProbeFieldPaths(externalCheckoutOptions *ExternalCheckoutOptions, validationErrorType ValidationErrorType) []string {
+	_, err := ConvertExternalCheckoutOptions(externalCheckoutOptions)
+	validationErrors, _ := err.(ValidationErrors)
+	var fieldPaths []string
+	for _, validationError := range validationErrors {
+		if validationError.Type() == validationErrorType {
+			fieldPaths = append(fieldPaths, validationError.FieldPath())
+		}
+	}
+	return fieldPaths
+}
+
+func setProbeValue(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Bool:
+		value.SetBool(true)
+	case reflect.String:
+		value.SetString(jsonSchemaProbeValue)
+	case reflect.Slice:
+		value.Set(reflect.ValueOf([]string{jsonSchemaProbeValue}))
+	case reflect.Ptr:
+		value.Set(reflect.New(value.Type().Elem()))
+	}
+}
+
+func getSecurityOptionsJSONSchemaDefName(securityOptionsType SecurityOptionsType) string {
+	return joinStrings(securityOptionsType.String(), "SecurityOptions")
+}
+
+func getJSONName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+func getJSONNameByFieldName(structType reflect.Type, fieldName string) string {
+	field, _ := structType.FieldByName(fieldName)
+	return getJSONName(field)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
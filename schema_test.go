@@ -0,0 +1,213 @@
+package scm
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestExternalCheckoutOptionsJSONSchemaAgreesWithValidation(t *testing.T) {
+	t.Parallel()
+	data, err := ExternalCheckoutOptionsJSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if schema["$schema"] != "https://json-schema.org/draft/2020-12/schema" {
+		t.Errorf("expected draft 2020-12, got %v", schema["$schema"])
+	}
+	for _, document := range []string{
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "branch": "master", "commit_id": "a40e854c17df0b1a98c90c250dc20e6cb2474dfa"}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "branch": "master", "commit_id": "a40e854", "security_options": {"type": "ssh", "private_key": "key", "strict_host_key_checking": true}}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "branch": "master", "commit_id": "a40e854", "security_options": {"type": "accessToken", "access_token": "token"}}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "branch": "master", "commit_id": "master"}`,
+		`{"type": "git", "user": "git", "host": "github.com", "branch": "master", "commit_id": "a40e854"}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "branch": "master"}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "ref": "refs/tags/v1.0", "commit_id": "a40e854"}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "ref": "refs/pull/123/head"}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "branch": "master", "ref": "v1.0"}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "commit_id": "a40e854"}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "branch": "master", "commit_id": "a40e854", "sparse_paths": ["lib", "spec/fixtures"]}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": ":peter-edge/smartystreets_ruby.git", "branch": "master", "commit_id": "a40e854", "sparse_paths": "lib"}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "clone_filter": "blob:limit=1m"}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "clone_filter": "blob:all"}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "submodules": {"paths": ["vendor/lib"], "shallow": true, "depth": 1, "jobs": 8}}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "submodules": {"disabled": true}}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "submodules": {"depth": -1}}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "submodules": {"recursive": true}}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "lfs": {"include": ["assets", "*.bin"], "exclude": ["assets/large"]}}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "lfs": {}}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "lfs": {"include": "assets"}}`,
+		`{"type": "git", "user": "git", "host": "github.com", "path": "", "branch": "master", "commit_id": "a40e854"}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854"}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "security_options": {"type": "accessToken", "access_token": "token"}}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "security_options": {"type": "accessToken"}}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "security_options": {"type": "ssh", "access_token": "token"}}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "security_options": {"type": "awsAccessKey", "access_key_id": "AKIDEXAMPLE", "secret_access_key": "secret"}}`,
+		`{"type": "github", "user": "peter-edge", "host": "github.com", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854"}`,
+		`{"type": "github", "user": "peter-edge", "repository": "smartystreets_ruby", "branch": "master", "commit_id": "a40e854", "unknown": "field"}`,
+		`{"type": "hg", "user": "hg", "host": "bitbucket.org", "path": "/durin42/hg-git", "changeset_id": "4538981d2c3f"}`,
+		`{"type": "hg", "user": "hg", "host": "bitbucket.org", "path": "/durin42/hg-git", "changeset_id": "tip"}`,
+		`{"type": "hg", "user": "hg", "host": "bitbucket.org", "path": "/durin42/hg-git", "changeset_id": "4538981d2c3f", "sparse_paths": ["hggit"]}`,
+		`{"type": "hg", "user": "hg", "host": "bitbucket.org", "path": "/durin42/hg-git", "changeset_id": "4538981d2c3f", "submodules": {"disabled": true}}`,
+		`{"type": "hg", "user": "hg", "host": "bitbucket.org", "path": "/durin42/hg-git", "changeset_id": "4538981d2c3f", "lfs": {}}`,
+		`{"type": "hg", "user": "hg", "host": "bitbucket.org", "path": "/durin42/hg-git", "changeset_id": "4538981d2c3f", "branch": "default"}`,
+		`{"type": "hg", "user": "hg", "host": "bitbucket.org", "path": "/durin42/hg-git", "changeset_id": "4538981d2c3f", "ref": "default"}`,
+		`{"type": "bitbucketGit", "user": "owner", "repository": "repo", "branch": "master", "commit_id": "d1f47a7", "security_options": {"type": "ssh"}}`,
+		`{"type": "bitbucketHg", "user": "durin42", "repository": "hg-git", "changeset_id": "4538981d2c3f3fcb594ad7f2ae7622380929e226"}`,
+		`{"type": "gitlab", "user": "group/sub", "repository": "banana", "branch": "master", "commit_id": "d1f47a7"}`,
+		`{"type": "gitea", "user": "codeship", "host": "gitea.example.com", "repository": "banana", "branch": "master", "commit_id": "d1f47a7"}`,
+		`{"type": "gitea", "user": "codeship", "repository": "banana", "branch": "master", "commit_id": "d1f47a7"}`,
+		`{"type": "azureDevOps", "organization": "codeship", "project": "fruit", "repository": "banana", "branch": "master", "commit_id": "d1f47a7", "security_options": {"type": "accessToken", "access_token": "token"}}`,
+		`{"type": "azureDevOps", "user": "codeship", "organization": "codeship", "project": "fruit", "repository": "banana", "branch": "master", "commit_id": "d1f47a7"}`,
+		`{"type": "codeCommit", "region": "us-east-1", "repository": "banana", "branch": "master", "commit_id": "d1f47a7"}`,
+		`{"type": "codeCommit", "region": "us-east-1", "repository": "banana", "branch": "master", "commit_id": "d1f47a7", "security_options": {"type": "awsAccessKey", "access_key_id": "AKIDEXAMPLE", "secret_access_key": "secret", "session_token": "token"}}`,
+		`{"type": "codeCommit", "region": "us-east-1", "repository": "banana", "branch": "master", "commit_id": "d1f47a7", "security_options": {"type": "awsAccessKey", "access_key_id": "AKIDEXAMPLE"}}`,
+		`{"type": "codeCommit", "region": "us-east-1", "repository": "banana", "ssh_key_id": "APKAEIBAERJR2EXAMPLE", "branch": "master", "commit_id": "d1f47a7", "security_options": {"type": "ssh"}}`,
+		`{"type": "codeCommit", "region": "us-east-1", "repository": "banana", "branch": "master", "commit_id": "d1f47a7", "security_options": {"type": "ssh"}}`,
+		`{"type": "codeCommit", "region": "us-east-1", "repository": "banana", "ssh_key_id": "APKAEIBAERJR2EXAMPLE", "branch": "master", "commit_id": "d1f47a7"}`,
+		`{"type": "codeCommit", "region": "US-EAST-1", "repository": "banana", "branch": "master", "commit_id": "d1f47a7"}`,
+		`{"type": "svn", "url": "https://svn.example.com/repo", "path": "/trunk", "revision": "1"}`,
+		`{"type": "svn", "url": "https://svn.example.com/repo", "revision": "1", "username": "user", "password": "password"}`,
+		`{"type": "svn", "url": "https://svn.example.com/repo", "revision": "1", "password": "password"}`,
+		`{"type": "svn", "url": "https://svn.example.com/repo"}`,
+		`{"type": "fossil", "url": "https://fossil.example.com/banana", "checkin_id": "trunk", "security_options": {"type": "ssh"}}`,
+		`{"type": "fossil", "url": "https://fossil.example.com/banana", "checkin_id": "trunk", "security_options": {"type": "accessToken", "access_token": "token"}}`,
+		`{"type": "local", "path": "/srv/git/banana.git", "branch": "master", "commit_id": "d1f47a7", "committer_name": "Peter", "email": "peter@example.com"}`,
+		`{"type": "local", "path": "/srv/git/banana.git", "branch": "master", "commit_id": "d1f47a7", "security_options": {"type": "ssh"}}`,
+		`{"type": "banana", "path": "/srv/git/banana.git"}`,
+		`{"path": "/srv/git/banana.git"}`,
+		`{"type": "local", "path": "/srv/git/banana.git", "branch": "master", "commit_id": "d1f47a7", "security_options": {}}`,
+	} {
+		var value interface{}
+		if err := json.Unmarshal([]byte(document), &value); err != nil {
+			t.Fatal(err)
+		}
+		schemaValid := isValidForTestJSONSchema(schema, schema, value)
+		goErr := validateTestExternalCheckoutOptionsJSON(document)
+		if schemaValid != (goErr == nil) {
+			t.Errorf("%s: schema valid %v, Go validation error %v", document, schemaValid, goErr)
+		}
+	}
+}
+
+func validateTestExternalCheckoutOptionsJSON(document string) error {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.DisallowUnknownFields()
+	externalCheckoutOptions := &ExternalCheckoutOptions{}
+	if err := decoder.Decode(externalCheckoutOptions); err != nil {
+		return err
+	}
+	_, err := ConvertExternalCheckoutOptions(externalCheckoutOptions)
+	return err
+}
+
+// isValidForTestJSONSchema evaluates the subset of JSON Schema that ExternalCheckoutOptionsJSONSchema uses.
+func isValidForTestJSONSchema(root map[string]interface{}, schema map[string]interface{}, value interface{}) bool {
+	if ref, ok := schema["$ref"].(string); ok {
+		defs := root["$defs"].(map[string]interface{})
+		return isValidForTestJSONSchema(root, defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{}), value)
+	}
+	if expected, ok := schema["const"]; ok && !reflect.DeepEqual(expected, value) {
+		return false
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, expected := range enum {
+			found = found || reflect.DeepEqual(expected, value)
+		}
+		if !found {
+			return false
+		}
+	}
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				return false
+			}
+		}
+		if dependentRequired, ok := schema["dependentRequired"].(map[string]interface{}); ok {
+			for name, dependencies := range dependentRequired {
+				if _, ok := object[name]; !ok {
+					continue
+				}
+				for _, dependency := range dependencies.([]interface{}) {
+					if _, ok := object[dependency.(string)]; !ok {
+						return false
+					}
+				}
+			}
+		}
+		for name, propertyValue := range object {
+			propertySchema, ok := properties[name].(map[string]interface{})
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return false
+				}
+				continue
+			}
+			if !isValidForTestJSONSchema(root, propertySchema, propertyValue) {
+				return false
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		if minLength, ok := schema["minLength"].(float64); ok && utf8.RuneCountInString(s) < int(minLength) {
+			return false
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(s) {
+			return false
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return false
+		}
+	case "integer":
+		f, ok := value.(float64)
+		if !ok || f != float64(int64(f)) {
+			return false
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && f < minimum {
+			return false
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, item := range array {
+			if !isValidForTestJSONSchema(root, schema["items"].(map[string]interface{}), item) {
+				return false
+			}
+		}
+	}
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		matches := 0
+		for _, branch := range oneOf {
+			if isValidForTestJSONSchema(root, branch.(map[string]interface{}), value) {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return false
+		}
+	}
+	return true
+}
@@ -6,19 +6,38 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
+	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/codeship/go-exec"
 )
 
 const (
 	clonePath = "clone"
+	// defaultSubmoduleJobs is how many submodules are fetched in parallel if SubmoduleOptions.Jobs is not set.
+	defaultSubmoduleJobs = 4
+	// fossilRepositoryFile is the name of the cloned repository database within a Fossil checkout.
+	fossilRepositoryFile = ".fossil"
+	// git aborts HTTP transfers that stay below gitLowSpeedLimit bytes per second for gitLowSpeedTime
+	// seconds, so that a stalled fetch fails instead of hanging.
+	gitLowSpeedLimit = "1000"
+	gitLowSpeedTime  = "60"
+)
+
+var (
+	// ssh gives up on hosts that do not answer within 30 seconds, or that stop answering keepalives for a minute.
+	sshTimeoutOptions = []string{"-o", "ConnectTimeout=30", "-o", "ServerAliveInterval=15", "-o", "ServerAliveCountMax=4"}
 )
 
 var (
 	ValidationErrorTypeRequiredFieldMissing                         ValidationErrorType = "RequiredFieldMissing"
 	ValidationErrorTypeFieldShouldNotBeSet                          ValidationErrorType = "FieldShouldNotBeSet"
+	ValidationErrorTypeInvalidFieldFormat                           ValidationErrorType = "InvalidFieldFormat"
 	ValidationErrorTypeSecurityNotImplementedForCheckoutOptionsType ValidationErrorType = "SecurityNotImplementedForCheckoutOptionsType"
 
 	errorSecurityNotImplementedForCheckoutOptionsType = errors.New("SecurityNotImplementedForCheckoutOptionsType")
@@ -29,6 +48,26 @@ type ValidationErrorType string
 type ValidationError interface {
 	error
 	Type() ValidationErrorType
+	// FieldPath is the dot-separated path to the offending field, such as SecurityOptions.AccessToken.
+	FieldPath() string
+}
+
+// ValidationErrors is every ValidationError found when validating a CheckoutOptions.
+type ValidationErrors []ValidationError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, validationError := range v {
+		messages[i] = validationError.Error()
+	}
+	return strings.Join(messages, ", ")
+}
+
+func (v ValidationErrors) errorOrNil() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
 }
 
 //go:generate gen-enumtype
@@ -42,9 +81,26 @@ type GitCheckoutOptions struct {
 	Host              string
 	Path              string
 	Branch            string
-	CommitID          string
-	CommitMessage     string
-	SecurityOptions   SecurityOptions
+	// Ref is a branch or tag name, or a fully qualified ref such as refs/pull/123/head, to check out
+	// instead of Branch. A name is looked up as a tag first and then as a branch, as git does.
+	Ref string
+	// CommitID is the commit to check out from Branch, or the commit Ref is expected to resolve to.
+	// If not set, the commit at the tip of Branch or Ref is checked out.
+	CommitID      string
+	CommitMessage string
+	// SparsePaths are the directories to check out in cone mode, with the others left out.
+	// If not set, the whole tree is checked out.
+	SparsePaths []string
+	// CloneFilter is the partial clone filter, one of blob:none, tree:0 or blob:limit=<n>.
+	// If the server does not support partial clone, a full clone is done instead.
+	// If not set and SparsePaths is set, blob:none is used.
+	CloneFilter string
+	// Submodules controls which submodules are checked out and how. If not set, all submodules
+	// are checked out recursively with their full history.
+	Submodules *SubmoduleOptions
+	// LFS fetches the Git LFS objects of the checkout. If not set, LFS files are left as pointer files.
+	LFS             *LFSOptions
+	SecurityOptions SecurityOptions
 }
 
 // @gen-enumtype CheckoutOptions github 1
@@ -55,8 +111,13 @@ type GithubCheckoutOptions struct {
 	Email             string
 	Repository        string
 	Branch            string
+	Ref               string
 	CommitID          string
 	CommitMessage     string
+	SparsePaths       []string
+	CloneFilter       string
+	Submodules        *SubmoduleOptions
+	LFS               *LFSOptions
 	SecurityOptions   SecurityOptions
 }
 
@@ -81,8 +142,13 @@ type BitbucketGitCheckoutOptions struct {
 	Email             string
 	Repository        string
 	Branch            string
+	Ref               string
 	CommitID          string
 	CommitMessage     string
+	SparsePaths       []string
+	CloneFilter       string
+	Submodules        *SubmoduleOptions
+	LFS               *LFSOptions
 	SecurityOptions   SecurityOptions
 }
 
@@ -106,11 +172,115 @@ type GitlabCheckoutOptions struct {
 	Email             string
 	Repository        string
 	Branch            string
+	Ref               string
+	CommitID          string
+	CommitMessage     string
+	SparsePaths       []string
+	CloneFilter       string
+	Submodules        *SubmoduleOptions
+	LFS               *LFSOptions
+	SecurityOptions   SecurityOptions
+}
+
+// @gen-enumtype CheckoutOptions gitea 6
+type GiteaCheckoutOptions struct {
+	User              string
+	CommitterName     string
+	CommitterUsername string
+	Email             string
+	Host              string
+	Repository        string
+	Branch            string
+	Ref               string
+	CommitID          string
+	CommitMessage     string
+	SparsePaths       []string
+	CloneFilter       string
+	Submodules        *SubmoduleOptions
+	LFS               *LFSOptions
+	SecurityOptions   SecurityOptions
+}
+
+// @gen-enumtype CheckoutOptions azureDevOps 7
+type AzureDevOpsCheckoutOptions struct {
+	CommitterName     string
+	CommitterUsername string
+	Email             string
+	Organization      string
+	Project           string
+	Repository        string
+	Branch            string
+	Ref               string
+	CommitID          string
+	CommitMessage     string
+	SparsePaths       []string
+	CloneFilter       string
+	Submodules        *SubmoduleOptions
+	LFS               *LFSOptions
+	SecurityOptions   SecurityOptions
+}
+
+// @gen-enumtype CheckoutOptions codeCommit 8
+type CodeCommitCheckoutOptions struct {
+	CommitterName     string
+	CommitterUsername string
+	Email             string
+	Region            string
+	Repository        string
+	SSHKeyID          string
+	Branch            string
+	Ref               string
 	CommitID          string
 	CommitMessage     string
+	SparsePaths       []string
+	CloneFilter       string
+	Submodules        *SubmoduleOptions
+	LFS               *LFSOptions
+	SecurityOptions   SecurityOptions
+}
+
+// @gen-enumtype CheckoutOptions svn 9
+type SvnCheckoutOptions struct {
+	CommitterName     string
+	CommitterUsername string
+	Email             string
+	URL               string
+	Path              string
+	Revision          string
+	Username          string
+	Password          string
+	CommitMessage     string
+	SecurityOptions   SecurityOptions
+}
+
+// @gen-enumtype CheckoutOptions fossil 10
+type FossilCheckoutOptions struct {
+	CommitterName     string
+	CommitterUsername string
+	Email             string
+	URL               string
+	CheckinID         string
+	CommitMessage     string
 	SecurityOptions   SecurityOptions
 }
 
+// @gen-enumtype CheckoutOptions local 11
+type LocalCheckoutOptions struct {
+	CommitterName     string
+	CommitterUsername string
+	Email             string
+	// Path is a repository directory, a file:// URL, or a git bundle file.
+	Path          string
+	Branch        string
+	Ref           string
+	CommitID      string
+	CommitMessage string
+	SparsePaths   []string
+	CloneFilter   string
+	Submodules    *SubmoduleOptions
+	LFS           *LFSOptions
+}
+
 // @gen-enumtype SecurityOptions ssh 0
 type SSHSecurityOptions struct {
 	StrictHostKeyChecking bool
@@ -122,6 +292,39 @@ type AccessTokenSecurityOptions struct {
 	AccessToken string
 }
 
+// @gen-enumtype SecurityOptions awsAccessKey 2
+type AWSAccessKeySecurityOptions struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// SubmoduleOptions controls how the submodules of a git repository are checked out.
+type SubmoduleOptions struct {
+	// Disabled skips all submodules, and the other fields cannot be set.
+	Disabled bool
+	// Paths are the paths of the submodules to check out, relative to the root of the repository.
+	// If not set, all submodules are checked out.
+	Paths []string
+	// Shallow fetches only the checked out commit of each submodule instead of its history.
+	Shallow bool
+	// Depth is how many levels of nested submodules are checked out, where 1 is only the
+	// submodules of the repository. If 0, all levels are checked out.
+	Depth int
+	// Jobs is how many submodules are fetched in parallel. If 0, 4 are fetched in parallel.
+	Jobs int
+}
+
+// LFSOptions controls which Git LFS objects of a git repository are fetched. The objects are
+// fetched with git lfs pull, which needs git-lfs to be installed, and not for submodules.
+type LFSOptions struct {
+	// Include are the patterns of the paths to fetch, as for git lfs pull --include.
+	// If not set, the objects of all paths are fetched.
+	Include []string
+	// Exclude are the patterns of the paths not to fetch, as for git lfs pull --exclude.
+	Exclude []string
+}
+
 func ConvertCheckoutOptions(checkoutOptions CheckoutOptions) (*ExternalCheckoutOptions, error) {
 	return convertCheckoutOptions(checkoutOptions)
 }
@@ -131,19 +334,33 @@ func ConvertSecurityOptions(securityOptions SecurityOptions) (*ExternalSecurityO
 }
 
 type ExternalCheckoutOptions struct {
-	Type              string                   `json:"type,omitempty" yaml:"type,omitempty"`
-	User              string                   `json:"user,omitempty" yaml:"user,omitempty"`
-	CommitterName     string                   `json:"committer_name,omitempty" yaml:"committer_name,omitempty"`
-	CommitterUsername string                   `json:"committer_username,omitempty" yaml:"committer_name,omitempty"`
-	Email             string                   `json:"email,omitempty" yaml:"email,omitempty"`
-	Host              string                   `json:"host,omitempty" yaml:"host,omitempty"`
-	Path              string                   `json:"path,omitempty" yaml:"path,omitempty"`
-	Repository        string                   `json:"repository,omitempty" yaml:"repository,omitempty"`
-	Branch            string                   `json:"branch,omitempty" yaml:"branch,omitempty"`
-	CommitID          string                   `json:"commit_id,omitempty" yaml:"commit_id,omitempty"`
-	CommitMessage     string                   `json:"commit_message,omitempty" yaml:"commit_message,omitempty"`
-	ChangesetID       string                   `json:"changeset_id,omitempty" yaml:"changeset_id,omitempty"`
-	SecurityOptions   *ExternalSecurityOptions `json:"security_options,omitempty" yaml:"security_options,omitempty"`
+	Type              string                    `json:"type,omitempty" yaml:"type,omitempty"`
+	User              string                    `json:"user,omitempty" yaml:"user,omitempty"`
+	CommitterName     string                    `json:"committer_name,omitempty" yaml:"committer_name,omitempty"`
+	CommitterUsername string                    `json:"committer_username,omitempty" yaml:"committer_username,omitempty"`
+	Email             string                    `json:"email,omitempty" yaml:"email,omitempty"`
+	Host              string                    `json:"host,omitempty" yaml:"host,omitempty"`
+	Path              string                    `json:"path,omitempty" yaml:"path,omitempty"`
+	Organization      string                    `json:"organization,omitempty" yaml:"organization,omitempty"`
+	Project           string                    `json:"project,omitempty" yaml:"project,omitempty"`
+	Region            string                    `json:"region,omitempty" yaml:"region,omitempty"`
+	SSHKeyID          string                    `json:"ssh_key_id,omitempty" yaml:"ssh_key_id,omitempty"`
+	URL               string                    `json:"url,omitempty" yaml:"url,omitempty"`
+	Username          string                    `json:"username,omitempty" yaml:"username,omitempty"`
+	Password          string                    `json:"password,omitempty" yaml:"password,omitempty"`
+	Repository        string                    `json:"repository,omitempty" yaml:"repository,omitempty"`
+	Branch            string                    `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Ref               string                    `json:"ref,omitempty" yaml:"ref,omitempty"`
+	CommitID          string                    `json:"commit_id,omitempty" yaml:"commit_id,omitempty"`
+	CommitMessage     string                    `json:"commit_message,omitempty" yaml:"commit_message,omitempty"`
+	ChangesetID       string                    `json:"changeset_id,omitempty" yaml:"changeset_id,omitempty"`
+	Revision          string                    `json:"revision,omitempty" yaml:"revision,omitempty"`
+	CheckinID         string                    `json:"checkin_id,omitempty" yaml:"checkin_id,omitempty"`
+	SparsePaths       []string                  `json:"sparse_paths,omitempty" yaml:"sparse_paths,omitempty"`
+	CloneFilter       string                    `json:"clone_filter,omitempty" yaml:"clone_filter,omitempty"`
+	Submodules        *ExternalSubmoduleOptions `json:"submodules,omitempty" yaml:"submodules,omitempty"`
+	LFS               *ExternalLFSOptions       `json:"lfs,omitempty" yaml:"lfs,omitempty"`
+	SecurityOptions   *ExternalSecurityOptions  `json:"security_options,omitempty" yaml:"security_options,omitempty"`
 }
 
 type ExternalSecurityOptions struct {
@@ -151,15 +368,69 @@ type ExternalSecurityOptions struct {
 	StrictHostKeyChecking bool   `json:"strict_host_key_checking,omitempty" yaml:"strict_host_key_checking,omitempty"`
 	PrivateKey            string `json:"private_key,omitempty" yaml:"private_key,omitempty"`
 	AccessToken           string `json:"access_token,omitempty" yaml:"access_token,omitempty"`
+	AccessKeyID           string `json:"access_key_id,omitempty" yaml:"access_key_id,omitempty"`
+	SecretAccessKey       string `json:"secret_access_key,omitempty" yaml:"secret_access_key,omitempty"`
+	SessionToken          string `json:"session_token,omitempty" yaml:"session_token,omitempty"`
+}
+
+type ExternalSubmoduleOptions struct {
+	Disabled bool     `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	Paths    []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+	Shallow  bool     `json:"shallow,omitempty" yaml:"shallow,omitempty"`
+	Depth    int      `json:"depth,omitempty" yaml:"depth,omitempty"`
+	Jobs     int      `json:"jobs,omitempty" yaml:"jobs,omitempty"`
+}
+
+type ExternalLFSOptions struct {
+	Include []string `json:"include,omitempty" yaml:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
 }
 
 func ConvertExternalCheckoutOptions(externalCheckoutOptions *ExternalCheckoutOptions) (CheckoutOptions, error) {
 	return convertExternalCheckoutOptions(externalCheckoutOptions)
 }
+
+// Validate checks checkoutOptions without performing a checkout.
+// If checkoutOptions is invalid, the returned error is a ValidationErrors listing every problem found.
+func Validate(checkoutOptions CheckoutOptions) error {
+	return validateCheckoutOptions(checkoutOptions)
+}
+
+type CheckoutResult struct {
+	Revision string
+	// Ref is the fully qualified ref that was checked out for a git repository, such as refs/heads/master
+	// or refs/tags/v1.0. If CommitID was not set, Revision is the commit Ref resolved to.
+	Ref string
+	// SparsePaths are the directories checked out for a sparse checkout, as reported by git sparse-checkout list.
+	SparsePaths []string
+	// CloneFilter is the partial clone filter the repository was cloned with. It is empty if no filter
+	// was set or the server does not support partial clone, in which case CloneFilterIgnored is true.
+	CloneFilter        string
+	CloneFilterIgnored bool
+	// ObjectsSize is the size in bytes of the objects cloned for the repository, not including submodules.
+	ObjectsSize int64
+	// Submodules are the submodules of the repository and their nested submodules, in the order of
+	// git submodule status --recursive, including the ones that were not checked out.
+	Submodules []*SubmoduleStatus
+	// LFSObjects is the number of Git LFS objects fetched for the checkout, and LFSObjectsSize their
+	// size in bytes. Both are 0 if LFS is not set.
+	LFSObjects     int
+	LFSObjectsSize int64
+}
+
+type SubmoduleStatus struct {
+	// Path is the slash-separated path of the submodule relative to the root of the checkout.
+	Path string
+	// CommitID is the commit of the submodule recorded in its parent repository.
+	CommitID string
+	// CheckedOut is false if the submodule was skipped.
+	CheckedOut bool
+}
+
 func Checkout(
 	checkoutOptions CheckoutOptions,
 	absolutePath string,
-) error {
+) (*CheckoutResult, error) {
 	return checkout(
 		checkoutOptions,
 		absolutePath,
@@ -190,8 +461,13 @@ func convertCheckoutOptions(checkoutOptions CheckoutOptions) (*ExternalCheckoutO
 				Host:              gitCheckoutOptions.Host,
 				Path:              gitCheckoutOptions.Path,
 				Branch:            gitCheckoutOptions.Branch,
+				Ref:               gitCheckoutOptions.Ref,
 				CommitID:          gitCheckoutOptions.CommitID,
 				CommitMessage:     gitCheckoutOptions.CommitMessage,
+				SparsePaths:       gitCheckoutOptions.SparsePaths,
+				CloneFilter:       gitCheckoutOptions.CloneFilter,
+				Submodules:        convertSubmoduleOptions(gitCheckoutOptions.Submodules),
+				LFS:               convertLFSOptions(gitCheckoutOptions.LFS),
 				SecurityOptions:   externalSecurityOptions,
 			}
 			return nil
@@ -213,8 +489,13 @@ func convertCheckoutOptions(checkoutOptions CheckoutOptions) (*ExternalCheckoutO
 				Email:             githubCheckoutOptions.Email,
 				Repository:        githubCheckoutOptions.Repository,
 				Branch:            githubCheckoutOptions.Branch,
+				Ref:               githubCheckoutOptions.Ref,
 				CommitID:          githubCheckoutOptions.CommitID,
 				CommitMessage:     githubCheckoutOptions.CommitMessage,
+				SparsePaths:       githubCheckoutOptions.SparsePaths,
+				CloneFilter:       githubCheckoutOptions.CloneFilter,
+				Submodules:        convertSubmoduleOptions(githubCheckoutOptions.Submodules),
+				LFS:               convertLFSOptions(githubCheckoutOptions.LFS),
 				SecurityOptions:   externalSecurityOptions,
 			}
 			return nil
@@ -259,8 +540,13 @@ func convertCheckoutOptions(checkoutOptions CheckoutOptions) (*ExternalCheckoutO
 				Email:             bitbucketGitCheckoutOptions.Email,
 				Repository:        bitbucketGitCheckoutOptions.Repository,
 				Branch:            bitbucketGitCheckoutOptions.Branch,
+				Ref:               bitbucketGitCheckoutOptions.Ref,
 				CommitID:          bitbucketGitCheckoutOptions.CommitID,
 				CommitMessage:     bitbucketGitCheckoutOptions.CommitMessage,
+				SparsePaths:       bitbucketGitCheckoutOptions.SparsePaths,
+				CloneFilter:       bitbucketGitCheckoutOptions.CloneFilter,
+				Submodules:        convertSubmoduleOptions(bitbucketGitCheckoutOptions.Submodules),
+				LFS:               convertLFSOptions(bitbucketGitCheckoutOptions.LFS),
 				SecurityOptions:   externalSecurityOptions,
 			}
 			return nil
@@ -305,12 +591,167 @@ func convertCheckoutOptions(checkoutOptions CheckoutOptions) (*ExternalCheckoutO
 				Email:             gitlabCheckoutOptions.Email,
 				Repository:        gitlabCheckoutOptions.Repository,
 				Branch:            gitlabCheckoutOptions.Branch,
+				Ref:               gitlabCheckoutOptions.Ref,
 				CommitID:          gitlabCheckoutOptions.CommitID,
 				CommitMessage:     gitlabCheckoutOptions.CommitMessage,
+				SparsePaths:       gitlabCheckoutOptions.SparsePaths,
+				CloneFilter:       gitlabCheckoutOptions.CloneFilter,
+				Submodules:        convertSubmoduleOptions(gitlabCheckoutOptions.Submodules),
+				LFS:               convertLFSOptions(gitlabCheckoutOptions.LFS),
+				SecurityOptions:   externalSecurityOptions,
+			}
+			return nil
+		},
+		func(giteaCheckoutOptions *GiteaCheckoutOptions) error {
+			var externalSecurityOptions *ExternalSecurityOptions
+			var err error
+			if giteaCheckoutOptions.SecurityOptions != nil {
+				externalSecurityOptions, err = ConvertSecurityOptions(giteaCheckoutOptions.SecurityOptions)
+				if err != nil {
+					return err
+				}
+			}
+			externalCheckoutOptions = &ExternalCheckoutOptions{
+				Type:              "gitea",
+				User:              giteaCheckoutOptions.User,
+				CommitterName:     giteaCheckoutOptions.CommitterName,
+				CommitterUsername: giteaCheckoutOptions.CommitterUsername,
+				Email:             giteaCheckoutOptions.Email,
+				Host:              giteaCheckoutOptions.Host,
+				Repository:        giteaCheckoutOptions.Repository,
+				Branch:            giteaCheckoutOptions.Branch,
+				Ref:               giteaCheckoutOptions.Ref,
+				CommitID:          giteaCheckoutOptions.CommitID,
+				CommitMessage:     giteaCheckoutOptions.CommitMessage,
+				SparsePaths:       giteaCheckoutOptions.SparsePaths,
+				CloneFilter:       giteaCheckoutOptions.CloneFilter,
+				Submodules:        convertSubmoduleOptions(giteaCheckoutOptions.Submodules),
+				LFS:               convertLFSOptions(giteaCheckoutOptions.LFS),
+				SecurityOptions:   externalSecurityOptions,
+			}
+			return nil
+		},
+		func(azureDevOpsCheckoutOptions *AzureDevOpsCheckoutOptions) error {
+			var externalSecurityOptions *ExternalSecurityOptions
+			var err error
+			if azureDevOpsCheckoutOptions.SecurityOptions != nil {
+				externalSecurityOptions, err = ConvertSecurityOptions(azureDevOpsCheckoutOptions.SecurityOptions)
+				if err != nil {
+					return err
+				}
+			}
+			externalCheckoutOptions = &ExternalCheckoutOptions{
+				Type:              "azureDevOps",
+				CommitterName:     azureDevOpsCheckoutOptions.CommitterName,
+				CommitterUsername: azureDevOpsCheckoutOptions.CommitterUsername,
+				Email:             azureDevOpsCheckoutOptions.Email,
+				Organization:      azureDevOpsCheckoutOptions.Organization,
+				Project:           azureDevOpsCheckoutOptions.Project,
+				Repository:        azureDevOpsCheckoutOptions.Repository,
+				Branch:            azureDevOpsCheckoutOptions.Branch,
+				Ref:               azureDevOpsCheckoutOptions.Ref,
+				CommitID:          azureDevOpsCheckoutOptions.CommitID,
+				CommitMessage:     azureDevOpsCheckoutOptions.CommitMessage,
+				SparsePaths:       azureDevOpsCheckoutOptions.SparsePaths,
+				CloneFilter:       azureDevOpsCheckoutOptions.CloneFilter,
+				Submodules:        convertSubmoduleOptions(azureDevOpsCheckoutOptions.Submodules),
+				LFS:               convertLFSOptions(azureDevOpsCheckoutOptions.LFS),
+				SecurityOptions:   externalSecurityOptions,
+			}
+			return nil
+		},
+		func(codeCommitCheckoutOptions *CodeCommitCheckoutOptions) error {
+			var externalSecurityOptions *ExternalSecurityOptions
+			var err error
+			if codeCommitCheckoutOptions.SecurityOptions != nil {
+				externalSecurityOptions, err = ConvertSecurityOptions(codeCommitCheckoutOptions.SecurityOptions)
+				if err != nil {
+					return err
+				}
+			}
+			externalCheckoutOptions = &ExternalCheckoutOptions{
+				Type:              "codeCommit",
+				CommitterName:     codeCommitCheckoutOptions.CommitterName,
+				CommitterUsername: codeCommitCheckoutOptions.CommitterUsername,
+				Email:             codeCommitCheckoutOptions.Email,
+				Region:            codeCommitCheckoutOptions.Region,
+				Repository:        codeCommitCheckoutOptions.Repository,
+				SSHKeyID:          codeCommitCheckoutOptions.SSHKeyID,
+				Branch:            codeCommitCheckoutOptions.Branch,
+				Ref:               codeCommitCheckoutOptions.Ref,
+				CommitID:          codeCommitCheckoutOptions.CommitID,
+				CommitMessage:     codeCommitCheckoutOptions.CommitMessage,
+				SparsePaths:       codeCommitCheckoutOptions.SparsePaths,
+				CloneFilter:       codeCommitCheckoutOptions.CloneFilter,
+				Submodules:        convertSubmoduleOptions(codeCommitCheckoutOptions.Submodules),
+				LFS:               convertLFSOptions(codeCommitCheckoutOptions.LFS),
+				SecurityOptions:   externalSecurityOptions,
+			}
+			return nil
+		},
+		func(svnCheckoutOptions *SvnCheckoutOptions) error {
+			var externalSecurityOptions *ExternalSecurityOptions
+			var err error
+			if svnCheckoutOptions.SecurityOptions != nil {
+				externalSecurityOptions, err = ConvertSecurityOptions(svnCheckoutOptions.SecurityOptions)
+				if err != nil {
+					return err
+				}
+			}
+			externalCheckoutOptions = &ExternalCheckoutOptions{
+				Type:              "svn",
+				CommitterName:     svnCheckoutOptions.CommitterName,
+				CommitterUsername: svnCheckoutOptions.CommitterUsername,
+				Email:             svnCheckoutOptions.Email,
+				URL:               svnCheckoutOptions.URL,
+				Path:              svnCheckoutOptions.Path,
+				Revision:          svnCheckoutOptions.Revision,
+				Username:          svnCheckoutOptions.Username,
+				Password:          svnCheckoutOptions.Password,
+				CommitMessage:     svnCheckoutOptions.CommitMessage,
+				SecurityOptions:   externalSecurityOptions,
+			}
+			return nil
+		},
+		func(fossilCheckoutOptions *FossilCheckoutOptions) error {
+			var externalSecurityOptions *ExternalSecurityOptions
+			var err error
+			if fossilCheckoutOptions.SecurityOptions != nil {
+				externalSecurityOptions, err = ConvertSecurityOptions(fossilCheckoutOptions.SecurityOptions)
+				if err != nil {
+					return err
+				}
+			}
+			externalCheckoutOptions = &ExternalCheckoutOptions{
+				Type:              "fossil",
+				CommitterName:     fossilCheckoutOptions.CommitterName,
+				CommitterUsername: fossilCheckoutOptions.CommitterUsername,
+				Email:             fossilCheckoutOptions.Email,
+				URL:               fossilCheckoutOptions.URL,
+				CheckinID:         fossilCheckoutOptions.CheckinID,
+				CommitMessage:     fossilCheckoutOptions.CommitMessage,
 				SecurityOptions:   externalSecurityOptions,
 			}
 			return nil
 		},
+		func(localCheckoutOptions *LocalCheckoutOptions) error {
+			externalCheckoutOptions = &ExternalCheckoutOptions{
+				Type:              "local",
+				CommitterName:     localCheckoutOptions.CommitterName,
+				CommitterUsername: localCheckoutOptions.CommitterUsername,
+				Email:             localCheckoutOptions.Email,
+				Path:              localCheckoutOptions.Path,
+				Branch:            localCheckoutOptions.Branch,
+				Ref:               localCheckoutOptions.Ref,
+				CommitID:          localCheckoutOptions.CommitID,
+				CommitMessage:     localCheckoutOptions.CommitMessage,
+				SparsePaths:       localCheckoutOptions.SparsePaths,
+				CloneFilter:       localCheckoutOptions.CloneFilter,
+				Submodules:        convertSubmoduleOptions(localCheckoutOptions.Submodules),
+				LFS:               convertLFSOptions(localCheckoutOptions.LFS),
+			}
+			return nil
+		},
 	); switchErr != nil {
 		return nil, switchErr
 	}
@@ -328,6 +769,8 @@ func convertSecurityOptions(securityOptions SecurityOptions) (*ExternalSecurityO
 				if err != nil {
 					return err
 				}
+				// reading drains PrivateKey, so put the key back for later use
+				sshSecurityOptions.PrivateKey = bytes.NewReader(data)
 				privateKeyString = string(data)
 			}
 			externalSecurityOptions = &ExternalSecurityOptions{
@@ -344,12 +787,67 @@ func convertSecurityOptions(securityOptions SecurityOptions) (*ExternalSecurityO
 			}
 			return nil
 		},
+		func(awsAccessKeySecurityOptions *AWSAccessKeySecurityOptions) error {
+			externalSecurityOptions = &ExternalSecurityOptions{
+				Type:            "awsAccessKey",
+				AccessKeyID:     awsAccessKeySecurityOptions.AccessKeyID,
+				SecretAccessKey: awsAccessKeySecurityOptions.SecretAccessKey,
+				SessionToken:    awsAccessKeySecurityOptions.SessionToken,
+			}
+			return nil
+		},
 	); switchErr != nil {
 		return nil, switchErr
 	}
 	return externalSecurityOptions, nil
 }
 
+func convertSubmoduleOptions(submoduleOptions *SubmoduleOptions) *ExternalSubmoduleOptions {
+	if submoduleOptions == nil {
+		return nil
+	}
+	return &ExternalSubmoduleOptions{
+		Disabled: submoduleOptions.Disabled,
+		Paths:    submoduleOptions.Paths,
+		Shallow:  submoduleOptions.Shallow,
+		Depth:    submoduleOptions.Depth,
+		Jobs:     submoduleOptions.Jobs,
+	}
+}
+
+func convertExternalSubmoduleOptions(externalSubmoduleOptions *ExternalSubmoduleOptions) *SubmoduleOptions {
+	if externalSubmoduleOptions == nil {
+		return nil
+	}
+	return &SubmoduleOptions{
+		Disabled: externalSubmoduleOptions.Disabled,
+		Paths:    externalSubmoduleOptions.Paths,
+		Shallow:  externalSubmoduleOptions.Shallow,
+		Depth:    externalSubmoduleOptions.Depth,
+		Jobs:     externalSubmoduleOptions.Jobs,
+	}
+}
+
+func convertLFSOptions(lfsOptions *LFSOptions) *ExternalLFSOptions {
+	if lfsOptions == nil {
+		return nil
+	}
+	return &ExternalLFSOptions{
+		Include: lfsOptions.Include,
+		Exclude: lfsOptions.Exclude,
+	}
+}
+
+func convertExternalLFSOptions(externalLFSOptions *ExternalLFSOptions) *LFSOptions {
+	if externalLFSOptions == nil {
+		return nil
+	}
+	return &LFSOptions{
+		Include: externalLFSOptions.Include,
+		Exclude: externalLFSOptions.Exclude,
+	}
+}
+
 func convertExternalCheckoutOptions(externalCheckoutOptions *ExternalCheckoutOptions) (CheckoutOptions, error) {
 	var securityOptions SecurityOptions
 	if externalCheckoutOptions.SecurityOptions != nil {
@@ -373,6 +871,13 @@ func convertExternalCheckoutOptions(externalCheckoutOptions *ExternalCheckoutOpt
 					AccessToken: externalCheckoutOptions.SecurityOptions.AccessToken,
 				}, nil
 			},
+			func() (*AWSAccessKeySecurityOptions, error) {
+				return &AWSAccessKeySecurityOptions{
+					AccessKeyID:     externalCheckoutOptions.SecurityOptions.AccessKeyID,
+					SecretAccessKey: externalCheckoutOptions.SecurityOptions.SecretAccessKey,
+					SessionToken:    externalCheckoutOptions.SecurityOptions.SessionToken,
+				}, nil
+			},
 		)
 		if err != nil {
 			return nil, err
@@ -382,7 +887,7 @@ func convertExternalCheckoutOptions(externalCheckoutOptions *ExternalCheckoutOpt
 	if err != nil {
 		return nil, err
 	}
-	return checkoutOptionsType.NewCheckoutOptions(
+	checkoutOptions, err := checkoutOptionsType.NewCheckoutOptions(
 		func() (*GitCheckoutOptions, error) {
 			return &GitCheckoutOptions{
 				User:              externalCheckoutOptions.User,
@@ -392,8 +897,13 @@ func convertExternalCheckoutOptions(externalCheckoutOptions *ExternalCheckoutOpt
 				Host:              externalCheckoutOptions.Host,
 				Path:              externalCheckoutOptions.Path,
 				Branch:            externalCheckoutOptions.Branch,
+				Ref:               externalCheckoutOptions.Ref,
 				CommitID:          externalCheckoutOptions.CommitID,
 				CommitMessage:     externalCheckoutOptions.CommitMessage,
+				SparsePaths:       externalCheckoutOptions.SparsePaths,
+				CloneFilter:       externalCheckoutOptions.CloneFilter,
+				Submodules:        convertExternalSubmoduleOptions(externalCheckoutOptions.Submodules),
+				LFS:               convertExternalLFSOptions(externalCheckoutOptions.LFS),
 				SecurityOptions:   securityOptions,
 			}, nil
 		},
@@ -405,8 +915,13 @@ func convertExternalCheckoutOptions(externalCheckoutOptions *ExternalCheckoutOpt
 				Email:             externalCheckoutOptions.Email,
 				Repository:        externalCheckoutOptions.Repository,
 				Branch:            externalCheckoutOptions.Branch,
+				Ref:               externalCheckoutOptions.Ref,
 				CommitID:          externalCheckoutOptions.CommitID,
 				CommitMessage:     externalCheckoutOptions.CommitMessage,
+				SparsePaths:       externalCheckoutOptions.SparsePaths,
+				CloneFilter:       externalCheckoutOptions.CloneFilter,
+				Submodules:        convertExternalSubmoduleOptions(externalCheckoutOptions.Submodules),
+				LFS:               convertExternalLFSOptions(externalCheckoutOptions.LFS),
 				SecurityOptions:   securityOptions,
 			}, nil
 		},
@@ -431,8 +946,13 @@ func convertExternalCheckoutOptions(externalCheckoutOptions *ExternalCheckoutOpt
 				Email:             externalCheckoutOptions.Email,
 				Repository:        externalCheckoutOptions.Repository,
 				Branch:            externalCheckoutOptions.Branch,
+				Ref:               externalCheckoutOptions.Ref,
 				CommitID:          externalCheckoutOptions.CommitID,
 				CommitMessage:     externalCheckoutOptions.CommitMessage,
+				SparsePaths:       externalCheckoutOptions.SparsePaths,
+				CloneFilter:       externalCheckoutOptions.CloneFilter,
+				Submodules:        convertExternalSubmoduleOptions(externalCheckoutOptions.Submodules),
+				LFS:               convertExternalLFSOptions(externalCheckoutOptions.LFS),
 				SecurityOptions:   securityOptions,
 			}, nil
 		},
@@ -456,52 +976,196 @@ func convertExternalCheckoutOptions(externalCheckoutOptions *ExternalCheckoutOpt
 				Email:             externalCheckoutOptions.Email,
 				Repository:        externalCheckoutOptions.Repository,
 				Branch:            externalCheckoutOptions.Branch,
+				Ref:               externalCheckoutOptions.Ref,
 				CommitID:          externalCheckoutOptions.CommitID,
 				CommitMessage:     externalCheckoutOptions.CommitMessage,
+				SparsePaths:       externalCheckoutOptions.SparsePaths,
+				CloneFilter:       externalCheckoutOptions.CloneFilter,
+				Submodules:        convertExternalSubmoduleOptions(externalCheckoutOptions.Submodules),
+				LFS:               convertExternalLFSOptions(externalCheckoutOptions.LFS),
 				SecurityOptions:   securityOptions,
 			}, nil
 
 		},
+		func() (*GiteaCheckoutOptions, error) {
+			return &GiteaCheckoutOptions{
+				User:              externalCheckoutOptions.User,
+				CommitterName:     externalCheckoutOptions.CommitterName,
+				CommitterUsername: externalCheckoutOptions.CommitterUsername,
+				Email:             externalCheckoutOptions.Email,
+				Host:              externalCheckoutOptions.Host,
+				Repository:        externalCheckoutOptions.Repository,
+				Branch:            externalCheckoutOptions.Branch,
+				Ref:               externalCheckoutOptions.Ref,
+				CommitID:          externalCheckoutOptions.CommitID,
+				CommitMessage:     externalCheckoutOptions.CommitMessage,
+				SparsePaths:       externalCheckoutOptions.SparsePaths,
+				CloneFilter:       externalCheckoutOptions.CloneFilter,
+				Submodules:        convertExternalSubmoduleOptions(externalCheckoutOptions.Submodules),
+				LFS:               convertExternalLFSOptions(externalCheckoutOptions.LFS),
+				SecurityOptions:   securityOptions,
+			}, nil
+		},
+		func() (*AzureDevOpsCheckoutOptions, error) {
+			return &AzureDevOpsCheckoutOptions{
+				CommitterName:     externalCheckoutOptions.CommitterName,
+				CommitterUsername: externalCheckoutOptions.CommitterUsername,
+				Email:             externalCheckoutOptions.Email,
+				Organization:      externalCheckoutOptions.Organization,
+				Project:           externalCheckoutOptions.Project,
+				Repository:        externalCheckoutOptions.Repository,
+				Branch:            externalCheckoutOptions.Branch,
+				Ref:               externalCheckoutOptions.Ref,
+				CommitID:          externalCheckoutOptions.CommitID,
+				CommitMessage:     externalCheckoutOptions.CommitMessage,
+				SparsePaths:       externalCheckoutOptions.SparsePaths,
+				CloneFilter:       externalCheckoutOptions.CloneFilter,
+				Submodules:        convertExternalSubmoduleOptions(externalCheckoutOptions.Submodules),
+				LFS:               convertExternalLFSOptions(externalCheckoutOptions.LFS),
+				SecurityOptions:   securityOptions,
+			}, nil
+		},
+		func() (*CodeCommitCheckoutOptions, error) {
+			return &CodeCommitCheckoutOptions{
+				CommitterName:     externalCheckoutOptions.CommitterName,
+				CommitterUsername: externalCheckoutOptions.CommitterUsername,
+				Email:             externalCheckoutOptions.Email,
+				Region:            externalCheckoutOptions.Region,
+				Repository:        externalCheckoutOptions.Repository,
+				SSHKeyID:          externalCheckoutOptions.SSHKeyID,
+				Branch:            externalCheckoutOptions.Branch,
+				Ref:               externalCheckoutOptions.Ref,
+				CommitID:          externalCheckoutOptions.CommitID,
+				CommitMessage:     externalCheckoutOptions.CommitMessage,
+				SparsePaths:       externalCheckoutOptions.SparsePaths,
+				CloneFilter:       externalCheckoutOptions.CloneFilter,
+				Submodules:        convertExternalSubmoduleOptions(externalCheckoutOptions.Submodules),
+				LFS:               convertExternalLFSOptions(externalCheckoutOptions.LFS),
+				SecurityOptions:   securityOptions,
+			}, nil
+		},
+		func() (*SvnCheckoutOptions, error) {
+			return &SvnCheckoutOptions{
+				CommitterName:     externalCheckoutOptions.CommitterName,
+				CommitterUsername: externalCheckoutOptions.CommitterUsername,
+				Email:             externalCheckoutOptions.Email,
+				URL:               externalCheckoutOptions.URL,
+				Path:              externalCheckoutOptions.Path,
+				Revision:          externalCheckoutOptions.Revision,
+				Username:          externalCheckoutOptions.Username,
+				Password:          externalCheckoutOptions.Password,
+				CommitMessage:     externalCheckoutOptions.CommitMessage,
+				SecurityOptions:   securityOptions,
+			}, nil
+		},
+		func() (*FossilCheckoutOptions, error) {
+			return &FossilCheckoutOptions{
+				CommitterName:     externalCheckoutOptions.CommitterName,
+				CommitterUsername: externalCheckoutOptions.CommitterUsername,
+				Email:             externalCheckoutOptions.Email,
+				URL:               externalCheckoutOptions.URL,
+				CheckinID:         externalCheckoutOptions.CheckinID,
+				CommitMessage:     externalCheckoutOptions.CommitMessage,
+				SecurityOptions:   securityOptions,
+			}, nil
+		},
+		func() (*LocalCheckoutOptions, error) {
+			return &LocalCheckoutOptions{
+				CommitterName:     externalCheckoutOptions.CommitterName,
+				CommitterUsername: externalCheckoutOptions.CommitterUsername,
+				Email:             externalCheckoutOptions.Email,
+				Path:              externalCheckoutOptions.Path,
+				Branch:            externalCheckoutOptions.Branch,
+				Ref:               externalCheckoutOptions.Ref,
+				CommitID:          externalCheckoutOptions.CommitID,
+				CommitMessage:     externalCheckoutOptions.CommitMessage,
+				SparsePaths:       externalCheckoutOptions.SparsePaths,
+				CloneFilter:       externalCheckoutOptions.CloneFilter,
+				Submodules:        convertExternalSubmoduleOptions(externalCheckoutOptions.Submodules),
+				LFS:               convertExternalLFSOptions(externalCheckoutOptions.LFS),
+			}, nil
+		},
 	)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateExternalCheckoutOptions(externalCheckoutOptions, checkoutOptions, securityOptions); err != nil {
+		return nil, err
+	}
+	return checkoutOptions, nil
 }
 
 func checkout(
 	checkoutOptions CheckoutOptions,
 	absolutePath string,
-) error {
+) (*CheckoutResult, error) {
 	if err := validateCheckoutOptions(checkoutOptions); err != nil {
-		return err
+		return nil, err
 	}
 	execClientProvider, err := exec.NewClientProvider(&exec.OsExecOptions{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	baseDir, path := filepath.Split(absolutePath)
 	executor, err := exec.NewOsExecutor(baseDir)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return CheckoutOptionsSwitch(
+	var checkoutResult *CheckoutResult
+	if err := CheckoutOptionsSwitch(
 		checkoutOptions,
 		func(gitCheckoutOptions *GitCheckoutOptions) error {
-			return checkoutGit(execClientProvider, gitCheckoutOptions, executor, path)
+			checkoutResult, err = checkoutGit(execClientProvider, gitCheckoutOptions, executor, path)
+			return err
 		},
 		func(githubCheckoutOptions *GithubCheckoutOptions) error {
-			return checkoutGithub(execClientProvider, githubCheckoutOptions, executor, path)
+			checkoutResult, err = checkoutGithub(execClientProvider, githubCheckoutOptions, executor, path)
+			return err
 		},
 		func(hgCheckoutOptions *HgCheckoutOptions) error {
-			return checkoutHg(execClientProvider, hgCheckoutOptions, executor, path)
+			checkoutResult, err = checkoutHg(execClientProvider, hgCheckoutOptions, executor, path)
+			return err
 		},
 		func(bitbucketGitCheckoutOptions *BitbucketGitCheckoutOptions) error {
-			return checkoutBitbucketGit(execClientProvider, bitbucketGitCheckoutOptions, executor, path)
+			checkoutResult, err = checkoutBitbucketGit(execClientProvider, bitbucketGitCheckoutOptions, executor, path)
+			return err
 		},
 		func(bitbucketHgCheckoutOptions *BitbucketHgCheckoutOptions) error {
-			return checkoutBitbucketHg(execClientProvider, bitbucketHgCheckoutOptions, executor, path)
+			checkoutResult, err = checkoutBitbucketHg(execClientProvider, bitbucketHgCheckoutOptions, executor, path)
+			return err
 		},
 		func(gitlabCheckoutOptions *GitlabCheckoutOptions) error {
-			return checkoutGitlab(execClientProvider, gitlabCheckoutOptions, executor, path)
+			checkoutResult, err = checkoutGitlab(execClientProvider, gitlabCheckoutOptions, executor, path)
+			return err
 		},
-	)
+		func(giteaCheckoutOptions *GiteaCheckoutOptions) error {
+			checkoutResult, err = checkoutGitea(execClientProvider, giteaCheckoutOptions, executor, path)
+			return err
+		},
+		func(azureDevOpsCheckoutOptions *AzureDevOpsCheckoutOptions) error {
+			checkoutResult, err = checkoutAzureDevOps(execClientProvider, azureDevOpsCheckoutOptions, executor, path)
+			return err
+		},
+		func(codeCommitCheckoutOptions *CodeCommitCheckoutOptions) error {
+			checkoutResult, err = checkoutCodeCommit(execClientProvider, codeCommitCheckoutOptions, executor, path)
+			return err
+		},
+		func(svnCheckoutOptions *SvnCheckoutOptions) error {
+			checkoutResult, err = checkoutSvn(execClientProvider, svnCheckoutOptions, executor, path)
+			return err
+		},
+		func(fossilCheckoutOptions *FossilCheckoutOptions) error {
+			checkoutResult, err = checkoutFossil(execClientProvider, fossilCheckoutOptions, executor, path)
+			return err
+		},
+		func(localCheckoutOptions *LocalCheckoutOptions) error {
+			checkoutResult, err = checkoutLocal(execClientProvider, localCheckoutOptions, executor, path)
+			return err
+		},
+	); err != nil {
+		return nil, err
+	}
+	return checkoutResult, nil
 }
 
 func checkoutGit(
@@ -509,14 +1173,14 @@ func checkoutGit(
 	gitCheckoutOptions *GitCheckoutOptions,
 	executor exec.Executor,
 	path string,
-) (retErr error) {
+) (_ *CheckoutResult, retErr error) {
 	var sshCommand string
 	var client exec.Client
 	var err error
 	if gitCheckoutOptions.SecurityOptions != nil {
 		sshCommand, client, err = getSSHCommand(execClientProvider, gitCheckoutOptions.SecurityOptions)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if client != nil {
 			defer func() {
@@ -526,11 +1190,15 @@ func checkoutGit(
 			}()
 		}
 	}
+	submoduleAuth, err := getGitSubmoduleAuth(gitCheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
 	url, err := getGitURL(gitCheckoutOptions)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return checkoutGitWithExecutor(executor, sshCommand, url, gitCheckoutOptions.Branch, gitCheckoutOptions.CommitID, path)
+	return checkoutGitWithExecutor(executor, sshCommand, submoduleAuth, url, gitCheckoutOptions.Branch, gitCheckoutOptions.Ref, gitCheckoutOptions.CommitID, gitCheckoutOptions.SparsePaths, gitCheckoutOptions.CloneFilter, gitCheckoutOptions.Submodules, gitCheckoutOptions.LFS, path)
 }
 
 func checkoutGithub(
@@ -538,14 +1206,14 @@ func checkoutGithub(
 	githubCheckoutOptions *GithubCheckoutOptions,
 	executor exec.Executor,
 	path string,
-) (retErr error) {
+) (_ *CheckoutResult, retErr error) {
 	var sshCommand string
 	var client exec.Client
 	var err error
 	if githubCheckoutOptions.SecurityOptions != nil {
 		sshCommand, client, err = getSSHCommand(execClientProvider, githubCheckoutOptions.SecurityOptions)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if client != nil {
 			defer func() {
@@ -555,11 +1223,15 @@ func checkoutGithub(
 			}()
 		}
 	}
+	submoduleAuth, err := getGitSubmoduleAuth(githubCheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
 	url, err := getGithubURL(githubCheckoutOptions)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return checkoutGitWithExecutor(executor, sshCommand, url, githubCheckoutOptions.Branch, githubCheckoutOptions.CommitID, path)
+	return checkoutGitWithExecutor(executor, sshCommand, submoduleAuth, url, githubCheckoutOptions.Branch, githubCheckoutOptions.Ref, githubCheckoutOptions.CommitID, githubCheckoutOptions.SparsePaths, githubCheckoutOptions.CloneFilter, githubCheckoutOptions.Submodules, githubCheckoutOptions.LFS, path)
 }
 
 func checkoutHg(
@@ -567,14 +1239,14 @@ func checkoutHg(
 	hgCheckoutOptions *HgCheckoutOptions,
 	executor exec.Executor,
 	path string,
-) (retErr error) {
+) (_ *CheckoutResult, retErr error) {
 	var sshCommand string
 	var client exec.Client
 	var err error
 	if hgCheckoutOptions.SecurityOptions != nil {
 		sshCommand, client, err = getSSHCommand(execClientProvider, hgCheckoutOptions.SecurityOptions)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if client != nil {
 			defer func() {
@@ -586,7 +1258,7 @@ func checkoutHg(
 	}
 	url, err := getHgURL(hgCheckoutOptions)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	return checkoutHgWithExecutor(executor, sshCommand, url, hgCheckoutOptions.ChangesetID, path)
 }
@@ -596,14 +1268,14 @@ func checkoutBitbucketGit(
 	bitbucketGitCheckoutOptions *BitbucketGitCheckoutOptions,
 	executor exec.Executor,
 	path string,
-) (retErr error) {
+) (_ *CheckoutResult, retErr error) {
 	var sshCommand string
 	var client exec.Client
 	var err error
 	if bitbucketGitCheckoutOptions.SecurityOptions != nil {
 		sshCommand, client, err = getSSHCommand(execClientProvider, bitbucketGitCheckoutOptions.SecurityOptions)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if client != nil {
 			defer func() {
@@ -613,11 +1285,15 @@ func checkoutBitbucketGit(
 			}()
 		}
 	}
+	submoduleAuth, err := getGitSubmoduleAuth(bitbucketGitCheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
 	url, err := getBitbucketGitURL(bitbucketGitCheckoutOptions)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return checkoutGitWithExecutor(executor, sshCommand, url, bitbucketGitCheckoutOptions.Branch, bitbucketGitCheckoutOptions.CommitID, path)
+	return checkoutGitWithExecutor(executor, sshCommand, submoduleAuth, url, bitbucketGitCheckoutOptions.Branch, bitbucketGitCheckoutOptions.Ref, bitbucketGitCheckoutOptions.CommitID, bitbucketGitCheckoutOptions.SparsePaths, bitbucketGitCheckoutOptions.CloneFilter, bitbucketGitCheckoutOptions.Submodules, bitbucketGitCheckoutOptions.LFS, path)
 }
 
 func checkoutBitbucketHg(
@@ -625,14 +1301,14 @@ func checkoutBitbucketHg(
 	bitbucketHgCheckoutOptions *BitbucketHgCheckoutOptions,
 	executor exec.Executor,
 	path string,
-) (retErr error) {
+) (_ *CheckoutResult, retErr error) {
 	var sshCommand string
 	var client exec.Client
 	var err error
 	if bitbucketHgCheckoutOptions.SecurityOptions != nil {
 		sshCommand, client, err = getSSHCommand(execClientProvider, bitbucketHgCheckoutOptions.SecurityOptions)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if client != nil {
 			defer func() {
@@ -644,11 +1320,134 @@ func checkoutBitbucketHg(
 	}
 	url, err := getBitbucketHgURL(bitbucketHgCheckoutOptions)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	return checkoutHgWithExecutor(executor, sshCommand, url, bitbucketHgCheckoutOptions.ChangesetID, path)
 }
 
+func checkoutSvn(
+	execClientProvider exec.ClientProvider,
+	svnCheckoutOptions *SvnCheckoutOptions,
+	executor exec.Executor,
+	path string,
+) (_ *CheckoutResult, retErr error) {
+	var sshCommand string
+	var client exec.Client
+	var err error
+	if svnCheckoutOptions.SecurityOptions != nil {
+		sshCommand, client, err = getSSHCommand(execClientProvider, svnCheckoutOptions.SecurityOptions)
+		if err != nil {
+			return nil, err
+		}
+		if client != nil {
+			defer func() {
+				if err := client.Destroy(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+		}
+	}
+	return checkoutSvnWithExecutor(
+		executor,
+		sshCommand,
+		getSvnURL(svnCheckoutOptions.URL, svnCheckoutOptions.Path),
+		svnCheckoutOptions.Revision,
+		svnCheckoutOptions.Username,
+		svnCheckoutOptions.Password,
+		path,
+	)
+}
+
+func checkoutFossil(
+	execClientProvider exec.ClientProvider,
+	fossilCheckoutOptions *FossilCheckoutOptions,
+	executor exec.Executor,
+	path string,
+) (_ *CheckoutResult, retErr error) {
+	var sshCommand string
+	var client exec.Client
+	var err error
+	if fossilCheckoutOptions.SecurityOptions != nil {
+		sshCommand, client, err = getSSHCommand(execClientProvider, fossilCheckoutOptions.SecurityOptions)
+		if err != nil {
+			return nil, err
+		}
+		if client != nil {
+			defer func() {
+				if err := client.Destroy(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+		}
+	}
+	return checkoutFossilWithExecutor(executor, sshCommand, fossilCheckoutOptions.URL, fossilCheckoutOptions.CheckinID, path)
+}
+
+func checkoutLocal(
+	execClientProvider exec.ClientProvider,
+	localCheckoutOptions *LocalCheckoutOptions,
+	executor exec.Executor,
+	path string,
+) (*CheckoutResult, error) {
+	url := localCheckoutOptions.Path
+	if !strings.HasPrefix(url, "file://") {
+		var err error
+		url, err = filepath.Abs(url)
+		if err != nil {
+			return nil, err
+		}
+		fileInfo, err := os.Stat(url)
+		if err != nil {
+			return nil, err
+		}
+		if fileInfo.Mode().IsRegular() {
+			if err := verifyGitBundle(execClientProvider, executor, url); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return checkoutGitWithExecutor(executor, "", nil, url, localCheckoutOptions.Branch, localCheckoutOptions.Ref, localCheckoutOptions.CommitID, localCheckoutOptions.SparsePaths, localCheckoutOptions.CloneFilter, localCheckoutOptions.Submodules, localCheckoutOptions.LFS, path)
+}
+
+// git bundle verify needs a repository to check prerequisites against, so the bundle
+// is verified against an empty repository in a temporary directory.
+func verifyGitBundle(
+	execClientProvider exec.ClientProvider,
+	executor exec.Executor,
+	bundlePath string,
+) (retErr error) {
+	client, err := execClientProvider.NewTempDirClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := client.Destroy(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	var initStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"git", "init", "--bare", "-q", client.DirPath()},
+			Stderr: &initStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return fmt.Errorf("CouldNotVerifyBundle: %v %v", err.Error(), initStderr.String())
+	}
+	var verifyStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"git", "--git-dir", client.DirPath(), "bundle", "verify", bundlePath},
+			Stderr: &verifyStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return fmt.Errorf("CouldNotVerifyBundle: %v %v", err.Error(), verifyStderr.String())
+	}
+	return nil
+}
+
 func getSSHCommand(execClientProvider exec.ClientProvider, securityOptions SecurityOptions) (string, exec.Client, error) {
 	var sshCommand string
 	var client exec.Client
@@ -683,6 +1482,7 @@ func getSSHCommand(execClientProvider exec.ClientProvider, securityOptions Secur
 				if err != nil {
 					return err
 				}
+				sshCommandArgs = append(sshCommandArgs, sshTimeoutOptions...)
 				sshCommandArgs = append(sshCommandArgs, "-i", client.Join(client.DirPath(), "id_rsa"))
 				sshCommand = strings.Join(sshCommandArgs, " ")
 			}
@@ -691,6 +1491,9 @@ func getSSHCommand(execClientProvider exec.ClientProvider, securityOptions Secur
 		func(accessTokenSecurityOptions *AccessTokenSecurityOptions) error {
 			return nil
 		},
+		func(awsAccessKeySecurityOptions *AWSAccessKeySecurityOptions) error {
+			return nil
+		},
 	); err != nil {
 		return "", nil, err
 	}
@@ -702,14 +1505,14 @@ func checkoutGitlab(
 	gitlabCheckoutOptions *GitlabCheckoutOptions,
 	executor exec.Executor,
 	path string,
-) (retErr error) {
+) (_ *CheckoutResult, retErr error) {
 	var sshCommand string
 	var client exec.Client
 	var err error
 	if gitlabCheckoutOptions.SecurityOptions != nil {
 		sshCommand, client, err = getSSHCommand(execClientProvider, gitlabCheckoutOptions.SecurityOptions)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if client != nil {
 			defer func() {
@@ -719,11 +1522,114 @@ func checkoutGitlab(
 			}()
 		}
 	}
+	submoduleAuth, err := getGitSubmoduleAuth(gitlabCheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
 	url, err := getGitlabURL(gitlabCheckoutOptions)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return checkoutGitWithExecutor(executor, sshCommand, submoduleAuth, url, gitlabCheckoutOptions.Branch, gitlabCheckoutOptions.Ref, gitlabCheckoutOptions.CommitID, gitlabCheckoutOptions.SparsePaths, gitlabCheckoutOptions.CloneFilter, gitlabCheckoutOptions.Submodules, gitlabCheckoutOptions.LFS, path)
+}
+
+func checkoutGitea(
+	execClientProvider exec.ClientProvider,
+	giteaCheckoutOptions *GiteaCheckoutOptions,
+	executor exec.Executor,
+	path string,
+) (_ *CheckoutResult, retErr error) {
+	var sshCommand string
+	var client exec.Client
+	var err error
+	if giteaCheckoutOptions.SecurityOptions != nil {
+		sshCommand, client, err = getSSHCommand(execClientProvider, giteaCheckoutOptions.SecurityOptions)
+		if err != nil {
+			return nil, err
+		}
+		if client != nil {
+			defer func() {
+				if err := client.Destroy(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+		}
+	}
+	submoduleAuth, err := getGitSubmoduleAuth(giteaCheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
+	url, err := getGiteaURL(giteaCheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
+	return checkoutGitWithExecutor(executor, sshCommand, submoduleAuth, url, giteaCheckoutOptions.Branch, giteaCheckoutOptions.Ref, giteaCheckoutOptions.CommitID, giteaCheckoutOptions.SparsePaths, giteaCheckoutOptions.CloneFilter, giteaCheckoutOptions.Submodules, giteaCheckoutOptions.LFS, path)
+}
+
+func checkoutAzureDevOps(
+	execClientProvider exec.ClientProvider,
+	azureDevOpsCheckoutOptions *AzureDevOpsCheckoutOptions,
+	executor exec.Executor,
+	path string,
+) (_ *CheckoutResult, retErr error) {
+	var sshCommand string
+	var client exec.Client
+	var err error
+	if azureDevOpsCheckoutOptions.SecurityOptions != nil {
+		sshCommand, client, err = getSSHCommand(execClientProvider, azureDevOpsCheckoutOptions.SecurityOptions)
+		if err != nil {
+			return nil, err
+		}
+		if client != nil {
+			defer func() {
+				if err := client.Destroy(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+		}
+	}
+	submoduleAuth, err := getGitSubmoduleAuth(azureDevOpsCheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
+	url, err := getAzureDevOpsURL(azureDevOpsCheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
+	return checkoutGitWithExecutor(executor, sshCommand, submoduleAuth, url, azureDevOpsCheckoutOptions.Branch, azureDevOpsCheckoutOptions.Ref, azureDevOpsCheckoutOptions.CommitID, azureDevOpsCheckoutOptions.SparsePaths, azureDevOpsCheckoutOptions.CloneFilter, azureDevOpsCheckoutOptions.Submodules, azureDevOpsCheckoutOptions.LFS, path)
+}
+
+func checkoutCodeCommit(
+	execClientProvider exec.ClientProvider,
+	codeCommitCheckoutOptions *CodeCommitCheckoutOptions,
+	executor exec.Executor,
+	path string,
+) (_ *CheckoutResult, retErr error) {
+	var sshCommand string
+	var client exec.Client
+	var err error
+	if codeCommitCheckoutOptions.SecurityOptions != nil {
+		sshCommand, client, err = getSSHCommand(execClientProvider, codeCommitCheckoutOptions.SecurityOptions)
+		if err != nil {
+			return nil, err
+		}
+		if client != nil {
+			defer func() {
+				if err := client.Destroy(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+		}
+	}
+	submoduleAuth, err := getGitSubmoduleAuth(codeCommitCheckoutOptions)
+	if err != nil {
+		return nil, err
+	}
+	url, err := getCodeCommitURL(codeCommitCheckoutOptions, time.Now())
+	if err != nil {
+		return nil, err
 	}
-	return checkoutGitWithExecutor(executor, sshCommand, url, gitlabCheckoutOptions.Branch, gitlabCheckoutOptions.CommitID, path)
+	return checkoutGitWithExecutor(executor, sshCommand, submoduleAuth, url, codeCommitCheckoutOptions.Branch, codeCommitCheckoutOptions.Ref, codeCommitCheckoutOptions.CommitID, codeCommitCheckoutOptions.SparsePaths, codeCommitCheckoutOptions.CloneFilter, codeCommitCheckoutOptions.Submodules, codeCommitCheckoutOptions.LFS, path)
 }
 
 func getGitURL(gitCheckoutOptions *GitCheckoutOptions) (string, error) {
@@ -747,8 +1653,8 @@ func getGitURL(gitCheckoutOptions *GitCheckoutOptions) (string, error) {
 
 func getGithubURL(githubCheckoutOptions *GithubCheckoutOptions) (string, error) {
 	if githubCheckoutOptions.SecurityOptions == nil {
-		return getGitReadOnlyURL(
-			"git",
+		// GitHub no longer serves git://, so anonymous checkouts go over HTTPS
+		return getHTTPSURL(
 			"github.com",
 			joinStrings("/", githubCheckoutOptions.User, "/", githubCheckoutOptions.Repository, ".git"),
 		), nil
@@ -785,11 +1691,10 @@ func getHgURL(hgCheckoutOptions *HgCheckoutOptions) (string, error) {
 
 func getBitbucketGitURL(bitbucketGitCheckoutOptions *BitbucketGitCheckoutOptions) (string, error) {
 	if bitbucketGitCheckoutOptions.SecurityOptions == nil {
-		return getSSHURL(
-			"ssh://",
-			"git",
+		// SSH needs a key, so anonymous checkouts go over HTTPS
+		return getHTTPSURL(
 			"bitbucket.org",
-			joinStrings(":", bitbucketGitCheckoutOptions.User, "/", bitbucketGitCheckoutOptions.Repository, ".git"),
+			joinStrings("/", bitbucketGitCheckoutOptions.User, "/", bitbucketGitCheckoutOptions.Repository, ".git"),
 		), nil
 	}
 	if bitbucketGitCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeSsh {
@@ -817,9 +1722,8 @@ func getBitbucketHgURL(bitbucketHgCheckoutOptions *BitbucketHgCheckoutOptions) (
 
 func getGitlabURL(gitlabCheckoutOptions *GitlabCheckoutOptions) (string, error) {
 	if gitlabCheckoutOptions.SecurityOptions == nil {
-		return getSSHURL(
-			"ssh://",
-			"git",
+		// SSH needs a key, so anonymous checkouts go over HTTPS
+		return getHTTPSURL(
 			"gitlab.com",
 			joinStrings("/", gitlabCheckoutOptions.User, "/", gitlabCheckoutOptions.Repository, ".git"),
 		), nil
@@ -835,6 +1739,94 @@ func getGitlabURL(gitlabCheckoutOptions *GitlabCheckoutOptions) (string, error)
 	return "", errorSecurityNotImplementedForCheckoutOptionsType
 }
 
+// Gitea (and Forgejo) do not run a git daemon, so anonymous checkouts go over HTTPS.
+// Gitea accepts an access token as the basic auth user with x-oauth-basic as the password,
+// the same as GitHub.
+func getGiteaURL(giteaCheckoutOptions *GiteaCheckoutOptions) (string, error) {
+	if giteaCheckoutOptions.SecurityOptions == nil {
+		return getHTTPSURL(
+			giteaCheckoutOptions.Host,
+			joinStrings("/", giteaCheckoutOptions.User, "/", giteaCheckoutOptions.Repository, ".git"),
+		), nil
+	}
+	if giteaCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeSsh {
+		return getSSHURL(
+			"",
+			"git",
+			giteaCheckoutOptions.Host,
+			joinStrings(":", giteaCheckoutOptions.User, "/", giteaCheckoutOptions.Repository, ".git"),
+		), nil
+	}
+	if giteaCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeAccessToken {
+		return getAccessTokenURL(
+			(giteaCheckoutOptions.SecurityOptions.(*AccessTokenSecurityOptions)).AccessToken,
+			giteaCheckoutOptions.Host,
+			joinStrings("/", giteaCheckoutOptions.User, "/", giteaCheckoutOptions.Repository, ".git"),
+		), nil
+	}
+	return "", errorSecurityNotImplementedForCheckoutOptionsType
+}
+
+// Azure Repos uses the v3 SSH path, and takes a personal access token as the basic auth password
+// with the organization as the user.
+func getAzureDevOpsURL(azureDevOpsCheckoutOptions *AzureDevOpsCheckoutOptions) (string, error) {
+	organization := url.PathEscape(azureDevOpsCheckoutOptions.Organization)
+	project := url.PathEscape(azureDevOpsCheckoutOptions.Project)
+	repository := url.PathEscape(azureDevOpsCheckoutOptions.Repository)
+	if azureDevOpsCheckoutOptions.SecurityOptions == nil {
+		return getHTTPSURL(
+			"dev.azure.com",
+			joinStrings("/", organization, "/", project, "/_git/", repository),
+		), nil
+	}
+	if azureDevOpsCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeSsh {
+		return getSSHURL(
+			"",
+			"git",
+			"ssh.dev.azure.com",
+			joinStrings(":v3/", organization, "/", project, "/", repository),
+		), nil
+	}
+	if azureDevOpsCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeAccessToken {
+		return getBasicAuthURL(
+			organization,
+			(azureDevOpsCheckoutOptions.SecurityOptions.(*AccessTokenSecurityOptions)).AccessToken,
+			"dev.azure.com",
+			joinStrings("/", organization, "/", project, "/_git/", repository),
+		), nil
+	}
+	return "", errorSecurityNotImplementedForCheckoutOptionsType
+}
+
+// Without security options, CodeCommit relies on a credential helper configured on the host.
+// SSH authenticates as the ID of the SSH public key uploaded to IAM, and access keys are
+// used to compute a SigV4 signed password at checkout time.
+func getCodeCommitURL(codeCommitCheckoutOptions *CodeCommitCheckoutOptions, now time.Time) (string, error) {
+	host := getCodeCommitHost(codeCommitCheckoutOptions.Region)
+	path := getCodeCommitPath(codeCommitCheckoutOptions.Repository)
+	if codeCommitCheckoutOptions.SecurityOptions == nil {
+		return getHTTPSURL(host, path), nil
+	}
+	if codeCommitCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeSsh {
+		return getSSHURL(
+			"ssh://",
+			codeCommitCheckoutOptions.SSHKeyID,
+			host,
+			path,
+		), nil
+	}
+	if codeCommitCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeAwsAccessKey {
+		return getCodeCommitSigV4URL(
+			codeCommitCheckoutOptions.SecurityOptions.(*AWSAccessKeySecurityOptions),
+			codeCommitCheckoutOptions.Region,
+			host,
+			path,
+			now,
+		), nil
+	}
+	return "", errorSecurityNotImplementedForCheckoutOptionsType
+}
+
 // TODO(pedge): user?
 func getGitReadOnlyURL(user string, host string, path string) string {
 	return joinStrings("git://", host, path)
@@ -844,52 +1836,400 @@ func getSSHURL(base string, user string, host string, path string) string {
 	return joinStrings(base, user, "@", host, path)
 }
 
+func getHTTPSURL(host string, path string) string {
+	return joinStrings("https://", host, path)
+}
+
+func getBasicAuthURL(user string, password string, host string, path string) string {
+	return joinStrings("https://", user, ":", password, "@", host, path)
+}
+
 func getAccessTokenURL(accessToken string, host string, path string) string {
 	return joinStrings("https://", accessToken, ":x-oauth-basic@", host, path)
 }
 
+// getSvnURL joins the repository URL and the path within it, which may be given with or
+// without a leading slash.
+func getSvnURL(url string, path string) string {
+	url = strings.TrimSuffix(url, "/")
+	if path = strings.TrimPrefix(path, "/"); path == "" {
+		return url
+	}
+	return joinStrings(url, "/", path)
+}
+
 func checkoutGitWithExecutor(
 	executor exec.Executor,
 	gitSSHCommand string,
+	submoduleAuth *gitSubmoduleAuth,
 	url string,
 	branch string,
+	ref string,
 	commitID string,
+	sparsePaths []string,
+	cloneFilter string,
+	submoduleOptions *SubmoduleOptions,
+	lfsOptions *LFSOptions,
 	path string,
-) error {
-	var cloneStderr bytes.Buffer
-	cmd := exec.Cmd{
-		// TODO(peter): if the commit id is more than 50 back, the checkout will fail
-		Args:   []string{"git", "clone", "--branch", branch, "--depth", "50", "--no-shallow-submodules", "--recursive", "--jobs", "4", url, path},
-		Stderr: &cloneStderr,
+) (*CheckoutResult, error) {
+	if cloneFilter == "" && len(sparsePaths) > 0 {
+		// only the blobs of the sparse paths are fetched, when the checkout needs them
+		cloneFilter = "blob:none"
+	}
+	env := []string{
+		"GIT_HTTP_LOW_SPEED_LIMIT=" + gitLowSpeedLimit,
+		"GIT_HTTP_LOW_SPEED_TIME=" + gitLowSpeedTime,
 	}
 	if gitSSHCommand != "" {
-		cmd.Env = []string{"GIT_SSH_COMMAND=" + gitSSHCommand}
+		env = append(env, "GIT_SSH_COMMAND="+gitSSHCommand)
+	}
+	// lfsEnv is the environment of git lfs pull, which must not skip smudging the objects it fetches
+	lfsEnv := env
+	if lfsOptions != nil {
+		// a git-lfs installed for the user would fetch every object on checkout, before Include and Exclude apply
+		env = append(append([]string{}, env...), "GIT_LFS_SKIP_SMUDGE=1")
+	}
+	// cloneBranch is the branch or tag that git clone --branch fetches, which is empty for refs it
+	// cannot fetch such as refs/pull/123/head, so they are fetched after cloning the default branch.
+	cloneBranch := branch
+	checkoutRef := joinStrings("refs/heads/", branch)
+	if ref != "" {
+		resolvedRef, resolvedCommitID, err := resolveGitRef(executor, env, url, ref)
+		if err != nil {
+			return nil, err
+		}
+		if commitID != "" && !strings.HasPrefix(resolvedCommitID, strings.ToLower(commitID)) {
+			return nil, fmt.Errorf("CommitIDMismatch: %v is %v, not %v", resolvedRef, resolvedCommitID, commitID)
+		}
+		cloneBranch = ""
+		for _, prefix := range []string{"refs/heads/", "refs/tags/"} {
+			if strings.HasPrefix(resolvedRef, prefix) {
+				cloneBranch = strings.TrimPrefix(resolvedRef, prefix)
+			}
+		}
+		checkoutRef = resolvedRef
+		commitID = resolvedCommitID
 	}
-	if err := executor.Execute(&cmd)(); err != nil {
-		// TODO(pedge)
-		return fmt.Errorf("CouldNotClone: %v %v", err.Error(), cloneStderr.String())
+	if commitID == "" {
+		// the tip of the branch, which git clone --branch checks out
+		commitID = "HEAD"
+	}
+	// TODO(peter): if the commit id is more than 50 back, the checkout will fail
+	args := []string{"git", "clone", "--depth", "50"}
+	if cloneBranch != "" {
+		args = append(args, "--branch", cloneBranch)
+	} else {
+		args = append(args, "--no-checkout")
+	}
+	if cloneFilter != "" {
+		args = append(args, "--filter="+cloneFilter)
+	}
+	if len(sparsePaths) > 0 {
+		args = append(args, "--sparse")
+	}
+	args = append(args, url, path)
+	var cloneStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   args,
+			Env:    env,
+			Stderr: &cloneStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return nil, fmt.Errorf("CouldNotClone: %v %v", err.Error(), cloneStderr.String())
+	}
+	if cloneBranch == "" {
+		var fetchStderr bytes.Buffer
+		if err := executor.Execute(
+			&exec.Cmd{
+				Args:   []string{"git", "fetch", "--depth", "50", "origin", checkoutRef},
+				Env:    env,
+				SubDir: path,
+				Stderr: &fetchStderr,
+			},
+		)(); err != nil {
+			// TODO(pedge)
+			return nil, fmt.Errorf("CouldNotFetch: %v %v", err.Error(), fetchStderr.String())
+		}
+	}
+	if len(sparsePaths) > 0 {
+		var sparseCheckoutStderr bytes.Buffer
+		if err := executor.Execute(
+			&exec.Cmd{
+				Args:   append([]string{"git", "sparse-checkout", "set", "--cone", "--"}, sparsePaths...),
+				Env:    env,
+				SubDir: path,
+				Stderr: &sparseCheckoutStderr,
+			},
+		)(); err != nil {
+			// TODO(pedge)
+			return nil, fmt.Errorf("CouldNotSetSparseCheckout: %v %v", err.Error(), sparseCheckoutStderr.String())
+		}
 	}
 	var checkoutStderr bytes.Buffer
 	if err := executor.Execute(
 		&exec.Cmd{
-			Args:   []string{"git", "checkout", "-f", commitID},
+			Args: []string{"git", "checkout", "-f", commitID},
+			// a partial clone fetches missing blobs on checkout
+			Env:    env,
 			SubDir: path,
 			Stderr: &checkoutStderr,
 		},
 	)(); err != nil {
 		// TODO(pedge)
-		return fmt.Errorf("CouldNotCheckout: %v %v", err.Error(), checkoutStderr.String())
+		return nil, fmt.Errorf("CouldNotCheckout: %v %v", err.Error(), checkoutStderr.String())
+	}
+	if lfsOptions != nil {
+		if err := pullGitLFS(executor, lfsEnv, lfsOptions, path); err != nil {
+			return nil, err
+		}
+	}
+	if err := updateGitSubmodules(executor, env, submoduleAuth, submoduleOptions, path); err != nil {
+		return nil, err
+	}
+	var revParseStdout bytes.Buffer
+	var revParseStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"git", "rev-parse", "HEAD"},
+			SubDir: path,
+			Stdout: &revParseStdout,
+			Stderr: &revParseStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return nil, fmt.Errorf("CouldNotGetRevision: %v %v", err.Error(), revParseStderr.String())
+	}
+	checkoutResult := &CheckoutResult{
+		Revision: strings.TrimSpace(revParseStdout.String()),
+		Ref:      checkoutRef,
+	}
+	if cloneFilter != "" {
+		// git falls back to a full clone with a warning if the filter cannot be used
+		if isGitCloneFilterIgnored(cloneStderr.String()) {
+			checkoutResult.CloneFilterIgnored = true
+		} else {
+			checkoutResult.CloneFilter = cloneFilter
+		}
+	}
+	objectsSize, err := getGitObjectsSize(executor, path)
+	if err != nil {
+		return nil, err
+	}
+	checkoutResult.ObjectsSize = objectsSize
+	submoduleStatuses, err := getGitSubmoduleStatuses(executor, path)
+	if err != nil {
+		return nil, err
+	}
+	checkoutResult.Submodules = submoduleStatuses
+	if lfsOptions != nil {
+		lfsObjects, lfsObjectsSize, err := getGitLFSObjects(executor, path)
+		if err != nil {
+			return nil, err
+		}
+		checkoutResult.LFSObjects = lfsObjects
+		checkoutResult.LFSObjectsSize = lfsObjectsSize
+	}
+	if len(sparsePaths) > 0 {
+		var sparseCheckoutListStdout bytes.Buffer
+		var sparseCheckoutListStderr bytes.Buffer
+		if err := executor.Execute(
+			&exec.Cmd{
+				Args:   []string{"git", "sparse-checkout", "list"},
+				SubDir: path,
+				Stdout: &sparseCheckoutListStdout,
+				Stderr: &sparseCheckoutListStderr,
+			},
+		)(); err != nil {
+			// TODO(pedge)
+			return nil, fmt.Errorf("CouldNotListSparseCheckout: %v %v", err.Error(), sparseCheckoutListStderr.String())
+		}
+		checkoutResult.SparsePaths = strings.Split(strings.TrimSpace(sparseCheckoutListStdout.String()), "\n")
+	}
+	return checkoutResult, nil
+}
+
+// updateGitSubmodules checks out the submodules at the commits recorded in the checked out commit.
+// git submodule update --recursive has no limit on how deep it goes, so a Depth is checked out one
+// level at a time, where each pass initializes the submodules of the ones checked out so far.
+// submoduleAuth can be nil.
+func updateGitSubmodules(executor exec.Executor, env []string, submoduleAuth *gitSubmoduleAuth, submoduleOptions *SubmoduleOptions, path string) error {
+	if submoduleOptions == nil {
+		submoduleOptions = &SubmoduleOptions{}
+	}
+	if submoduleOptions.Disabled {
+		return nil
+	}
+	gitArgs := []string{"git"}
+	if submoduleAuth != nil {
+		for _, config := range submoduleAuth.configs {
+			gitArgs = append(gitArgs, "-c", config)
+		}
+		env = append(append([]string{}, env...), submoduleAuth.env...)
+	}
+	jobs := submoduleOptions.Jobs
+	if jobs == 0 {
+		jobs = defaultSubmoduleJobs
+	}
+	updateArgs := []string{"submodule", "update", "--init", "--jobs", strconv.Itoa(jobs)}
+	if submoduleOptions.Shallow {
+		updateArgs = append(updateArgs, "--depth", "1")
+	}
+	args := append(append([]string{}, gitArgs...), updateArgs...)
+	if submoduleOptions.Depth == 0 {
+		args = append(args, "--recursive")
+	}
+	if len(submoduleOptions.Paths) > 0 {
+		args = append(append(args, "--"), submoduleOptions.Paths...)
+	}
+	argsList := [][]string{args}
+	for level := 1; level < submoduleOptions.Depth; level++ {
+		// the settings given with -c are passed on to the commands run in the submodules
+		foreachArgs := append(append([]string{}, gitArgs...), "submodule", "foreach", "--quiet", "--recursive", "git")
+		argsList = append(argsList, append(foreachArgs, updateArgs...))
+	}
+	for _, args := range argsList {
+		var stderr bytes.Buffer
+		if err := executor.Execute(
+			&exec.Cmd{
+				Args:   args,
+				Env:    env,
+				SubDir: path,
+				Stderr: &stderr,
+			},
+		)(); err != nil {
+			// TODO(pedge)
+			return fmt.Errorf("CouldNotUpdateSubmodules: %v %v", err.Error(), stderr.String())
+		}
 	}
 	return nil
 }
 
+// getGitSubmoduleStatuses parses the output of git submodule status --recursive, where each line is
+// a status character, the commit ID, the path and, for checked out submodules, the output of git describe.
+func getGitSubmoduleStatuses(executor exec.Executor, path string) ([]*SubmoduleStatus, error) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"git", "submodule", "status", "--recursive"},
+			SubDir: path,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return nil, fmt.Errorf("CouldNotGetSubmoduleStatus: %v %v", err.Error(), stderr.String())
+	}
+	var submoduleStatuses []*SubmoduleStatus
+	for _, line := range strings.Split(strings.TrimRight(stdout.String(), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		split := strings.SplitN(line[1:], " ", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("CouldNotGetSubmoduleStatus: %v", line)
+		}
+		submodulePath := split[1]
+		if line[0] != '-' {
+			if index := strings.LastIndex(submodulePath, " ("); index != -1 {
+				submodulePath = submodulePath[:index]
+			}
+		}
+		submoduleStatuses = append(submoduleStatuses, &SubmoduleStatus{
+			Path:       submodulePath,
+			CommitID:   split[0],
+			CheckedOut: line[0] != '-',
+		})
+	}
+	return submoduleStatuses, nil
+}
+
+// resolveGitRef looks up ref on the remote at url with git ls-remote, and returns the fully qualified
+// ref and the commit it points to. A name that is not fully qualified is looked up as a tag and then
+// as a branch, and ls-remote lists annotated tags twice, where the entry ending in ^{} is the commit.
+func resolveGitRef(executor exec.Executor, env []string, url string, ref string) (string, string, error) {
+	candidates := []string{ref}
+	if !strings.HasPrefix(ref, "refs/") {
+		candidates = []string{joinStrings("refs/tags/", ref), joinStrings("refs/heads/", ref)}
+	}
+	args := []string{"git", "ls-remote", url}
+	for _, candidate := range candidates {
+		args = append(args, candidate, joinStrings(candidate, "^{}"))
+	}
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   args,
+			Env:    env,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return "", "", fmt.Errorf("CouldNotResolveRef: %v %v", err.Error(), stderr.String())
+	}
+	// ls-remote also lists refs that only end with a candidate, so only exact names are used
+	commitIDs := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimRight(stdout.String(), "\n"), "\n") {
+		if split := strings.SplitN(line, "\t", 2); len(split) == 2 {
+			commitIDs[split[1]] = split[0]
+		}
+	}
+	for _, candidate := range candidates {
+		for _, name := range []string{joinStrings(candidate, "^{}"), candidate} {
+			if commitID, ok := commitIDs[name]; ok {
+				return candidate, commitID, nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("RefNotFound: %v", ref)
+}
+
+func isGitCloneFilterIgnored(cloneStderr string) bool {
+	return strings.Contains(cloneStderr, "filtering not recognized by server") ||
+		strings.Contains(cloneStderr, "--filter is ignored")
+}
+
+// getGitObjectsSize adds up the loose and packed object sizes reported by git count-objects in KiB.
+func getGitObjectsSize(executor exec.Executor, path string) (int64, error) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"git", "count-objects", "-v"},
+			SubDir: path,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return 0, fmt.Errorf("CouldNotCountObjects: %v %v", err.Error(), stderr.String())
+	}
+	var objectsSize int64
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		split := strings.SplitN(line, ": ", 2)
+		if len(split) != 2 || (split[0] != "size" && split[0] != "size-pack") {
+			continue
+		}
+		size, err := strconv.ParseInt(split[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("CouldNotCountObjects: %v", line)
+		}
+		objectsSize += size * 1024
+	}
+	return objectsSize, nil
+}
+
 func checkoutHgWithExecutor(
 	executor exec.Executor,
 	sshCommand string,
 	url string,
 	changesetID string,
 	path string,
-) error {
+) (*CheckoutResult, error) {
 	args := []string{"hg", "clone", url, path}
 	if sshCommand != "" {
 		args = []string{"hg", "clone", "--ssh", sshCommand, url, path}
@@ -902,7 +2242,7 @@ func checkoutHgWithExecutor(
 		},
 	)(); err != nil {
 		// TODO(pedge)
-		return fmt.Errorf("CouldNotClone: %v %v", err.Error(), cloneStderr.String())
+		return nil, fmt.Errorf("CouldNotClone: %v %v", err.Error(), cloneStderr.String())
 	}
 	var updateStderr bytes.Buffer
 	if err := executor.Execute(
@@ -912,9 +2252,145 @@ func checkoutHgWithExecutor(
 		},
 	)(); err != nil {
 		// TODO(pedge)
-		return fmt.Errorf("CouldNotUpdate: %v %v", err.Error(), updateStderr.String())
+		return nil, fmt.Errorf("CouldNotUpdate: %v %v", err.Error(), updateStderr.String())
 	}
-	return nil
+	var logStdout bytes.Buffer
+	var logStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"hg", "log", "--cwd", path, "-r", ".", "--template", "{node}"},
+			Stdout: &logStdout,
+			Stderr: &logStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return nil, fmt.Errorf("CouldNotGetRevision: %v %v", err.Error(), logStderr.String())
+	}
+	return &CheckoutResult{
+		Revision: strings.TrimSpace(logStdout.String()),
+	}, nil
+}
+
+func checkoutSvnWithExecutor(
+	executor exec.Executor,
+	sshCommand string,
+	url string,
+	revision string,
+	username string,
+	password string,
+	path string,
+) (*CheckoutResult, error) {
+	args := []string{"svn", "checkout", "--non-interactive", "-r", revision}
+	if username != "" {
+		args = append(args, "--username", username)
+	}
+	var checkoutStderr bytes.Buffer
+	cmd := exec.Cmd{
+		Stderr: &checkoutStderr,
+	}
+	// the password is read from stdin so that it is not visible in the process list
+	if password != "" {
+		args = append(args, "--password-from-stdin", "--no-auth-cache")
+		cmd.Stdin = strings.NewReader(password + "\n")
+	}
+	cmd.Args = append(args, url, path)
+	if sshCommand != "" {
+		cmd.Env = []string{"SVN_SSH=" + sshCommand}
+	}
+	if err := executor.Execute(&cmd)(); err != nil {
+		// TODO(pedge)
+		return nil, fmt.Errorf("CouldNotCheckout: %v %v", err.Error(), checkoutStderr.String())
+	}
+	var infoStdout bytes.Buffer
+	var infoStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"svn", "info", "--show-item", "revision", path},
+			Stdout: &infoStdout,
+			Stderr: &infoStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return nil, fmt.Errorf("CouldNotGetRevision: %v %v", err.Error(), infoStderr.String())
+	}
+	return &CheckoutResult{
+		Revision: strings.TrimSpace(infoStdout.String()),
+	}, nil
+}
+
+// Fossil keeps the repository database separate from the checkout, so the clone is stored
+// as fossilRepositoryFile inside the checkout directory and then opened in place.
+func checkoutFossilWithExecutor(
+	executor exec.Executor,
+	sshCommand string,
+	url string,
+	checkinID string,
+	path string,
+) (*CheckoutResult, error) {
+	var mkdirStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"mkdir", "-p", path},
+			Stderr: &mkdirStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return nil, fmt.Errorf("CouldNotClone: %v %v", err.Error(), mkdirStderr.String())
+	}
+	args := []string{"fossil", "clone"}
+	if sshCommand != "" {
+		args = append(args, "--ssh-command", sshCommand)
+	}
+	var cloneStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   append(args, url, fossilRepositoryFile),
+			SubDir: path,
+			Stderr: &cloneStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return nil, fmt.Errorf("CouldNotClone: %v %v", err.Error(), cloneStderr.String())
+	}
+	var openStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"fossil", "open", "--force", fossilRepositoryFile, checkinID},
+			SubDir: path,
+			Stderr: &openStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return nil, fmt.Errorf("CouldNotCheckout: %v %v", err.Error(), openStderr.String())
+	}
+	var infoStdout bytes.Buffer
+	var infoStderr bytes.Buffer
+	if err := executor.Execute(
+		&exec.Cmd{
+			Args:   []string{"fossil", "info"},
+			SubDir: path,
+			Stdout: &infoStdout,
+			Stderr: &infoStderr,
+		},
+	)(); err != nil {
+		// TODO(pedge)
+		return nil, fmt.Errorf("CouldNotGetRevision: %v %v", err.Error(), infoStderr.String())
+	}
+	return &CheckoutResult{
+		Revision: getFossilInfoCheckout(infoStdout.String()),
+	}, nil
+}
+
+// getFossilInfoCheckout returns the check-in hash from the checkout line of fossil info,
+// which looks like "checkout:     <hash> <date> <time> UTC".
+func getFossilInfoCheckout(info string) string {
+	for _, line := range strings.Split(info, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "checkout:" {
+			return fields[1]
+		}
+	}
+	return ""
 }
 
 func joinStrings(elems ...string) string {
@@ -941,6 +2417,50 @@ func (v *validationError) Type() ValidationErrorType {
 	return v.errorType
 }
 
+func (v *validationError) FieldPath() string {
+	return v.tags["fieldPath"]
+}
+
+// validateExternalCheckoutOptions validates the CheckoutOptions converted from externalCheckoutOptions,
+// and rejects any field of externalCheckoutOptions that the CheckoutOptions type does not have,
+// such as Host for github.
+func validateExternalCheckoutOptions(
+	externalCheckoutOptions *ExternalCheckoutOptions,
+	checkoutOptions CheckoutOptions,
+	securityOptions SecurityOptions,
+) error {
+	validationErrors := getExternalFieldsShouldNotBeSet(externalCheckoutOptions, checkoutOptions)
+	if securityOptions != nil {
+		validationErrors = append(validationErrors, getExternalFieldsShouldNotBeSet(externalCheckoutOptions.SecurityOptions, securityOptions, "SecurityOptions")...)
+	}
+	if err := validateCheckoutOptions(checkoutOptions); err != nil {
+		checkoutValidationErrors, ok := err.(ValidationErrors)
+		if !ok {
+			return err
+		}
+		validationErrors = append(validationErrors, checkoutValidationErrors...)
+	}
+	return validationErrors.errorOrNil()
+}
+
+// getExternalFieldsShouldNotBeSet returns an error for every non-zero field of the external struct
+// that has no field of the same name in the internal struct.
+func getExternalFieldsShouldNotBeSet(external interface{}, internal interface{}, fieldPath ...string) ValidationErrors {
+	externalValue := reflect.ValueOf(external).Elem()
+	internalType := reflect.TypeOf(internal).Elem()
+	var validationErrors ValidationErrors
+	for i := 0; i < externalValue.NumField(); i++ {
+		name := externalValue.Type().Field(i).Name
+		if name == "Type" || externalValue.Field(i).IsZero() {
+			continue
+		}
+		if _, ok := internalType.FieldByName(name); !ok {
+			validationErrors = append(validationErrors, newValidationErrorFieldShouldNotBeSet("*ExternalCheckoutOptions", append(fieldPath, name)...))
+		}
+	}
+	return validationErrors
+}
+
 func validateCheckoutOptions(checkoutOptions CheckoutOptions) error {
 	return CheckoutOptionsSwitch(
 		checkoutOptions,
@@ -950,155 +2470,469 @@ func validateCheckoutOptions(checkoutOptions CheckoutOptions) error {
 		validateBitbucketGitCheckoutOptions,
 		validateBitbucketHgCheckoutOptions,
 		validateGitlabCheckoutOptions,
+		validateGiteaCheckoutOptions,
+		validateAzureDevOpsCheckoutOptions,
+		validateCodeCommitCheckoutOptions,
+		validateSvnCheckoutOptions,
+		validateFossilCheckoutOptions,
+		validateLocalCheckoutOptions,
 	)
 }
 
 func validateGitCheckoutOptions(gitCheckoutOptions *GitCheckoutOptions) error {
+	var validationErrors ValidationErrors
 	if gitCheckoutOptions.User == "" {
-		return newValidationErrorRequiredFieldMissing("*GitCheckoutOptions", "User")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*GitCheckoutOptions", "User"))
 	}
 	if gitCheckoutOptions.Host == "" {
-		return newValidationErrorRequiredFieldMissing("*GitCheckoutOptions", "Host")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*GitCheckoutOptions", "Host"))
+	} else if !isValidHost(gitCheckoutOptions.Host) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GitCheckoutOptions", gitCheckoutOptions.Host, "Host"))
 	}
 	if gitCheckoutOptions.Path == "" {
-		return newValidationErrorRequiredFieldMissing("*GitCheckoutOptions", "Path")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*GitCheckoutOptions", "Path"))
+	}
+	validationErrors = append(validationErrors, validateBranchAndRef(gitCheckoutOptions.Branch, gitCheckoutOptions.Ref, "*GitCheckoutOptions")...)
+	if gitCheckoutOptions.CommitID != "" && !isValidCommitID(gitCheckoutOptions.CommitID) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GitCheckoutOptions", gitCheckoutOptions.CommitID, "CommitID"))
 	}
-	if gitCheckoutOptions.Branch == "" {
-		return newValidationErrorRequiredFieldMissing("*GitCheckoutOptions", "Branch")
+	validationErrors = append(validationErrors, validateSparsePaths(gitCheckoutOptions.SparsePaths, "*GitCheckoutOptions")...)
+	if gitCheckoutOptions.CloneFilter != "" && !isValidCloneFilter(gitCheckoutOptions.CloneFilter) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GitCheckoutOptions", gitCheckoutOptions.CloneFilter, "CloneFilter"))
 	}
-	if gitCheckoutOptions.CommitID == "" {
-		return newValidationErrorRequiredFieldMissing("*GitCheckoutOptions", "CommitID")
+	if gitCheckoutOptions.Submodules != nil {
+		validationErrors = append(validationErrors, validateSubmoduleOptions(gitCheckoutOptions.Submodules, "*GitCheckoutOptions")...)
+	}
+	if gitCheckoutOptions.LFS != nil {
+		validationErrors = append(validationErrors, validateLFSOptions(gitCheckoutOptions.LFS, "*GitCheckoutOptions")...)
 	}
 	if gitCheckoutOptions.SecurityOptions != nil {
-		if err := validateSecurityOptions(gitCheckoutOptions.SecurityOptions, CheckoutOptionsTypeGit, SecurityOptionsTypeSsh); err != nil {
-			return nil
-		}
+		validationErrors = append(validationErrors, validateSecurityOptions(gitCheckoutOptions.SecurityOptions, "*GitCheckoutOptions", CheckoutOptionsTypeGit, SecurityOptionsTypeSsh)...)
 	}
-	return nil
+	return validationErrors.errorOrNil()
 }
 
 func validateGithubCheckoutOptions(githubCheckoutOptions *GithubCheckoutOptions) error {
+	var validationErrors ValidationErrors
 	if githubCheckoutOptions.User == "" {
-		return newValidationErrorRequiredFieldMissing("*GithubCheckoutOptions", "User")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*GithubCheckoutOptions", "User"))
+	} else if !isValidRepositorySlug(githubCheckoutOptions.User) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GithubCheckoutOptions", githubCheckoutOptions.User, "User"))
 	}
 	if githubCheckoutOptions.Repository == "" {
-		return newValidationErrorRequiredFieldMissing("*GithubCheckoutOptions", "Repository")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*GithubCheckoutOptions", "Repository"))
+	} else if !isValidRepositorySlug(githubCheckoutOptions.Repository) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GithubCheckoutOptions", githubCheckoutOptions.Repository, "Repository"))
+	}
+	validationErrors = append(validationErrors, validateBranchAndRef(githubCheckoutOptions.Branch, githubCheckoutOptions.Ref, "*GithubCheckoutOptions")...)
+	if githubCheckoutOptions.CommitID != "" && !isValidCommitID(githubCheckoutOptions.CommitID) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GithubCheckoutOptions", githubCheckoutOptions.CommitID, "CommitID"))
 	}
-	if githubCheckoutOptions.Branch == "" {
-		return newValidationErrorRequiredFieldMissing("*GithubCheckoutOptions", "Branch")
+	validationErrors = append(validationErrors, validateSparsePaths(githubCheckoutOptions.SparsePaths, "*GithubCheckoutOptions")...)
+	if githubCheckoutOptions.CloneFilter != "" && !isValidCloneFilter(githubCheckoutOptions.CloneFilter) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GithubCheckoutOptions", githubCheckoutOptions.CloneFilter, "CloneFilter"))
 	}
-	if githubCheckoutOptions.CommitID == "" {
-		return newValidationErrorRequiredFieldMissing("*GithubCheckoutOptions", "CommitID")
+	if githubCheckoutOptions.Submodules != nil {
+		validationErrors = append(validationErrors, validateSubmoduleOptions(githubCheckoutOptions.Submodules, "*GithubCheckoutOptions")...)
+	}
+	if githubCheckoutOptions.LFS != nil {
+		validationErrors = append(validationErrors, validateLFSOptions(githubCheckoutOptions.LFS, "*GithubCheckoutOptions")...)
 	}
 	if githubCheckoutOptions.SecurityOptions != nil {
-		if err := validateSecurityOptions(githubCheckoutOptions.SecurityOptions, CheckoutOptionsTypeGithub, SecurityOptionsTypeSsh, SecurityOptionsTypeAccessToken); err != nil {
-			return nil
-		}
+		validationErrors = append(validationErrors, validateSecurityOptions(githubCheckoutOptions.SecurityOptions, "*GithubCheckoutOptions", CheckoutOptionsTypeGithub, SecurityOptionsTypeSsh, SecurityOptionsTypeAccessToken)...)
 	}
-	return nil
+	return validationErrors.errorOrNil()
 }
 
 func validateHgCheckoutOptions(hgCheckoutOptions *HgCheckoutOptions) error {
+	var validationErrors ValidationErrors
 	if hgCheckoutOptions.User == "" {
-		return newValidationErrorRequiredFieldMissing("*HgCheckoutOptions", "User")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*HgCheckoutOptions", "User"))
 	}
 	if hgCheckoutOptions.Host == "" {
-		return newValidationErrorRequiredFieldMissing("*HgCheckoutOptions", "Host")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*HgCheckoutOptions", "Host"))
+	} else if !isValidHost(hgCheckoutOptions.Host) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*HgCheckoutOptions", hgCheckoutOptions.Host, "Host"))
 	}
 	if hgCheckoutOptions.Path == "" {
-		return newValidationErrorRequiredFieldMissing("*HgCheckoutOptions", "Path")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*HgCheckoutOptions", "Path"))
 	}
 	if hgCheckoutOptions.ChangesetID == "" {
-		return newValidationErrorRequiredFieldMissing("*HgCheckoutOptions", "ChangesetID")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*HgCheckoutOptions", "ChangesetID"))
+	} else if !isValidChangesetID(hgCheckoutOptions.ChangesetID) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*HgCheckoutOptions", hgCheckoutOptions.ChangesetID, "ChangesetID"))
 	}
 	if hgCheckoutOptions.SecurityOptions != nil {
-		if err := validateSecurityOptions(hgCheckoutOptions.SecurityOptions, CheckoutOptionsTypeHg, SecurityOptionsTypeSsh); err != nil {
-			return nil
-		}
+		validationErrors = append(validationErrors, validateSecurityOptions(hgCheckoutOptions.SecurityOptions, "*HgCheckoutOptions", CheckoutOptionsTypeHg, SecurityOptionsTypeSsh)...)
 	}
-	return nil
+	return validationErrors.errorOrNil()
 }
 
 func validateBitbucketGitCheckoutOptions(bitbucketGitCheckoutOptions *BitbucketGitCheckoutOptions) error {
+	var validationErrors ValidationErrors
 	if bitbucketGitCheckoutOptions.User == "" {
-		return newValidationErrorRequiredFieldMissing("*BitbucketGitCheckoutOptions", "User")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*BitbucketGitCheckoutOptions", "User"))
+	} else if !isValidRepositorySlug(bitbucketGitCheckoutOptions.User) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*BitbucketGitCheckoutOptions", bitbucketGitCheckoutOptions.User, "User"))
 	}
 	if bitbucketGitCheckoutOptions.Repository == "" {
-		return newValidationErrorRequiredFieldMissing("*BitbucketGitCheckoutOptions", "Repository")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*BitbucketGitCheckoutOptions", "Repository"))
+	} else if !isValidRepositorySlug(bitbucketGitCheckoutOptions.Repository) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*BitbucketGitCheckoutOptions", bitbucketGitCheckoutOptions.Repository, "Repository"))
+	}
+	validationErrors = append(validationErrors, validateBranchAndRef(bitbucketGitCheckoutOptions.Branch, bitbucketGitCheckoutOptions.Ref, "*BitbucketGitCheckoutOptions")...)
+	if bitbucketGitCheckoutOptions.CommitID != "" && !isValidCommitID(bitbucketGitCheckoutOptions.CommitID) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*BitbucketGitCheckoutOptions", bitbucketGitCheckoutOptions.CommitID, "CommitID"))
+	}
+	validationErrors = append(validationErrors, validateSparsePaths(bitbucketGitCheckoutOptions.SparsePaths, "*BitbucketGitCheckoutOptions")...)
+	if bitbucketGitCheckoutOptions.CloneFilter != "" && !isValidCloneFilter(bitbucketGitCheckoutOptions.CloneFilter) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*BitbucketGitCheckoutOptions", bitbucketGitCheckoutOptions.CloneFilter, "CloneFilter"))
 	}
-	if bitbucketGitCheckoutOptions.Branch == "" {
-		return newValidationErrorRequiredFieldMissing("*BitbucketGitCheckoutOptions", "Branch")
+	if bitbucketGitCheckoutOptions.Submodules != nil {
+		validationErrors = append(validationErrors, validateSubmoduleOptions(bitbucketGitCheckoutOptions.Submodules, "*BitbucketGitCheckoutOptions")...)
 	}
-	if bitbucketGitCheckoutOptions.CommitID == "" {
-		return newValidationErrorRequiredFieldMissing("*BitbucketGitCheckoutOptions", "CommitID")
+	if bitbucketGitCheckoutOptions.LFS != nil {
+		validationErrors = append(validationErrors, validateLFSOptions(bitbucketGitCheckoutOptions.LFS, "*BitbucketGitCheckoutOptions")...)
 	}
 	if bitbucketGitCheckoutOptions.SecurityOptions != nil {
-		if err := validateSecurityOptions(bitbucketGitCheckoutOptions.SecurityOptions, CheckoutOptionsTypeBitbucketGit, SecurityOptionsTypeSsh); err != nil {
-			return nil
-		}
+		validationErrors = append(validationErrors, validateSecurityOptions(bitbucketGitCheckoutOptions.SecurityOptions, "*BitbucketGitCheckoutOptions", CheckoutOptionsTypeBitbucketGit, SecurityOptionsTypeSsh)...)
 	}
-	return nil
+	return validationErrors.errorOrNil()
 }
 
 func validateBitbucketHgCheckoutOptions(bitbucketHgCheckoutOptions *BitbucketHgCheckoutOptions) error {
+	var validationErrors ValidationErrors
 	if bitbucketHgCheckoutOptions.User == "" {
-		return newValidationErrorRequiredFieldMissing("*BitbucketHgCheckoutOptions", "User")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*BitbucketHgCheckoutOptions", "User"))
+	} else if !isValidRepositorySlug(bitbucketHgCheckoutOptions.User) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*BitbucketHgCheckoutOptions", bitbucketHgCheckoutOptions.User, "User"))
 	}
 	if bitbucketHgCheckoutOptions.Repository == "" {
-		return newValidationErrorRequiredFieldMissing("*BitbucketHgCheckoutOptions", "Repository")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*BitbucketHgCheckoutOptions", "Repository"))
+	} else if !isValidRepositorySlug(bitbucketHgCheckoutOptions.Repository) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*BitbucketHgCheckoutOptions", bitbucketHgCheckoutOptions.Repository, "Repository"))
 	}
 	if bitbucketHgCheckoutOptions.ChangesetID == "" {
-		return newValidationErrorRequiredFieldMissing("*BitbucketHgCheckoutOptions", "ChangesetID")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*BitbucketHgCheckoutOptions", "ChangesetID"))
+	} else if !isValidChangesetID(bitbucketHgCheckoutOptions.ChangesetID) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*BitbucketHgCheckoutOptions", bitbucketHgCheckoutOptions.ChangesetID, "ChangesetID"))
 	}
 	if bitbucketHgCheckoutOptions.SecurityOptions != nil {
-		if err := validateSecurityOptions(bitbucketHgCheckoutOptions.SecurityOptions, CheckoutOptionsTypeBitbucketHg, SecurityOptionsTypeSsh); err != nil {
-			return nil
-		}
+		validationErrors = append(validationErrors, validateSecurityOptions(bitbucketHgCheckoutOptions.SecurityOptions, "*BitbucketHgCheckoutOptions", CheckoutOptionsTypeBitbucketHg, SecurityOptionsTypeSsh)...)
 	}
-	return nil
+	return validationErrors.errorOrNil()
 }
 
 func validateGitlabCheckoutOptions(gitlabCheckoutOptions *GitlabCheckoutOptions) error {
+	var validationErrors ValidationErrors
 	if gitlabCheckoutOptions.User == "" {
-		return newValidationErrorRequiredFieldMissing("*GitlabCheckoutOptions", "User")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*GitlabCheckoutOptions", "User"))
+	} else if !isValidRepositoryNamespace(gitlabCheckoutOptions.User) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GitlabCheckoutOptions", gitlabCheckoutOptions.User, "User"))
 	}
 	if gitlabCheckoutOptions.Repository == "" {
-		return newValidationErrorRequiredFieldMissing("*GitlabCheckoutOptions", "Repository")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*GitlabCheckoutOptions", "Repository"))
+	} else if !isValidRepositorySlug(gitlabCheckoutOptions.Repository) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GitlabCheckoutOptions", gitlabCheckoutOptions.Repository, "Repository"))
+	}
+	validationErrors = append(validationErrors, validateBranchAndRef(gitlabCheckoutOptions.Branch, gitlabCheckoutOptions.Ref, "*GitlabCheckoutOptions")...)
+	if gitlabCheckoutOptions.CommitID != "" && !isValidCommitID(gitlabCheckoutOptions.CommitID) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GitlabCheckoutOptions", gitlabCheckoutOptions.CommitID, "CommitID"))
 	}
-	if gitlabCheckoutOptions.Branch == "" {
-		return newValidationErrorRequiredFieldMissing("*GitlabCheckoutOptions", "Branch")
+	validationErrors = append(validationErrors, validateSparsePaths(gitlabCheckoutOptions.SparsePaths, "*GitlabCheckoutOptions")...)
+	if gitlabCheckoutOptions.CloneFilter != "" && !isValidCloneFilter(gitlabCheckoutOptions.CloneFilter) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GitlabCheckoutOptions", gitlabCheckoutOptions.CloneFilter, "CloneFilter"))
 	}
-	if gitlabCheckoutOptions.CommitID == "" {
-		return newValidationErrorRequiredFieldMissing("*GitlabCheckoutOptions", "CommitID")
+	if gitlabCheckoutOptions.Submodules != nil {
+		validationErrors = append(validationErrors, validateSubmoduleOptions(gitlabCheckoutOptions.Submodules, "*GitlabCheckoutOptions")...)
+	}
+	if gitlabCheckoutOptions.LFS != nil {
+		validationErrors = append(validationErrors, validateLFSOptions(gitlabCheckoutOptions.LFS, "*GitlabCheckoutOptions")...)
 	}
 	if gitlabCheckoutOptions.SecurityOptions != nil {
-		if err := validateSecurityOptions(gitlabCheckoutOptions.SecurityOptions, CheckoutOptionsTypeGitlab, SecurityOptionsTypeSsh, SecurityOptionsTypeAccessToken); err != nil {
-			return nil
+		validationErrors = append(validationErrors, validateSecurityOptions(gitlabCheckoutOptions.SecurityOptions, "*GitlabCheckoutOptions", CheckoutOptionsTypeGitlab, SecurityOptionsTypeSsh, SecurityOptionsTypeAccessToken)...)
+	}
+	return validationErrors.errorOrNil()
+}
+
+func validateGiteaCheckoutOptions(giteaCheckoutOptions *GiteaCheckoutOptions) error {
+	var validationErrors ValidationErrors
+	if giteaCheckoutOptions.User == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*GiteaCheckoutOptions", "User"))
+	} else if !isValidRepositorySlug(giteaCheckoutOptions.User) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GiteaCheckoutOptions", giteaCheckoutOptions.User, "User"))
+	}
+	if giteaCheckoutOptions.Host == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*GiteaCheckoutOptions", "Host"))
+	} else if !isValidHost(giteaCheckoutOptions.Host) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GiteaCheckoutOptions", giteaCheckoutOptions.Host, "Host"))
+	}
+	if giteaCheckoutOptions.Repository == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*GiteaCheckoutOptions", "Repository"))
+	} else if !isValidRepositorySlug(giteaCheckoutOptions.Repository) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GiteaCheckoutOptions", giteaCheckoutOptions.Repository, "Repository"))
+	}
+	validationErrors = append(validationErrors, validateBranchAndRef(giteaCheckoutOptions.Branch, giteaCheckoutOptions.Ref, "*GiteaCheckoutOptions")...)
+	if giteaCheckoutOptions.CommitID != "" && !isValidCommitID(giteaCheckoutOptions.CommitID) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GiteaCheckoutOptions", giteaCheckoutOptions.CommitID, "CommitID"))
+	}
+	validationErrors = append(validationErrors, validateSparsePaths(giteaCheckoutOptions.SparsePaths, "*GiteaCheckoutOptions")...)
+	if giteaCheckoutOptions.CloneFilter != "" && !isValidCloneFilter(giteaCheckoutOptions.CloneFilter) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*GiteaCheckoutOptions", giteaCheckoutOptions.CloneFilter, "CloneFilter"))
+	}
+	if giteaCheckoutOptions.Submodules != nil {
+		validationErrors = append(validationErrors, validateSubmoduleOptions(giteaCheckoutOptions.Submodules, "*GiteaCheckoutOptions")...)
+	}
+	if giteaCheckoutOptions.LFS != nil {
+		validationErrors = append(validationErrors, validateLFSOptions(giteaCheckoutOptions.LFS, "*GiteaCheckoutOptions")...)
+	}
+	if giteaCheckoutOptions.SecurityOptions != nil {
+		validationErrors = append(validationErrors, validateSecurityOptions(giteaCheckoutOptions.SecurityOptions, "*GiteaCheckoutOptions", CheckoutOptionsTypeGitea, SecurityOptionsTypeSsh, SecurityOptionsTypeAccessToken)...)
+	}
+	return validationErrors.errorOrNil()
+}
+
+func validateAzureDevOpsCheckoutOptions(azureDevOpsCheckoutOptions *AzureDevOpsCheckoutOptions) error {
+	var validationErrors ValidationErrors
+	if azureDevOpsCheckoutOptions.Organization == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*AzureDevOpsCheckoutOptions", "Organization"))
+	}
+	if azureDevOpsCheckoutOptions.Project == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*AzureDevOpsCheckoutOptions", "Project"))
+	}
+	if azureDevOpsCheckoutOptions.Repository == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*AzureDevOpsCheckoutOptions", "Repository"))
+	}
+	validationErrors = append(validationErrors, validateBranchAndRef(azureDevOpsCheckoutOptions.Branch, azureDevOpsCheckoutOptions.Ref, "*AzureDevOpsCheckoutOptions")...)
+	if azureDevOpsCheckoutOptions.CommitID != "" && !isValidCommitID(azureDevOpsCheckoutOptions.CommitID) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*AzureDevOpsCheckoutOptions", azureDevOpsCheckoutOptions.CommitID, "CommitID"))
+	}
+	validationErrors = append(validationErrors, validateSparsePaths(azureDevOpsCheckoutOptions.SparsePaths, "*AzureDevOpsCheckoutOptions")...)
+	if azureDevOpsCheckoutOptions.CloneFilter != "" && !isValidCloneFilter(azureDevOpsCheckoutOptions.CloneFilter) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*AzureDevOpsCheckoutOptions", azureDevOpsCheckoutOptions.CloneFilter, "CloneFilter"))
+	}
+	if azureDevOpsCheckoutOptions.Submodules != nil {
+		validationErrors = append(validationErrors, validateSubmoduleOptions(azureDevOpsCheckoutOptions.Submodules, "*AzureDevOpsCheckoutOptions")...)
+	}
+	if azureDevOpsCheckoutOptions.LFS != nil {
+		validationErrors = append(validationErrors, validateLFSOptions(azureDevOpsCheckoutOptions.LFS, "*AzureDevOpsCheckoutOptions")...)
+	}
+	if azureDevOpsCheckoutOptions.SecurityOptions != nil {
+		validationErrors = append(validationErrors, validateSecurityOptions(azureDevOpsCheckoutOptions.SecurityOptions, "*AzureDevOpsCheckoutOptions", CheckoutOptionsTypeAzureDevOps, SecurityOptionsTypeSsh, SecurityOptionsTypeAccessToken)...)
+	}
+	return validationErrors.errorOrNil()
+}
+
+func validateCodeCommitCheckoutOptions(codeCommitCheckoutOptions *CodeCommitCheckoutOptions) error {
+	var validationErrors ValidationErrors
+	if codeCommitCheckoutOptions.Region == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*CodeCommitCheckoutOptions", "Region"))
+	} else if !isValidAWSRegion(codeCommitCheckoutOptions.Region) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*CodeCommitCheckoutOptions", codeCommitCheckoutOptions.Region, "Region"))
+	}
+	if codeCommitCheckoutOptions.Repository == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*CodeCommitCheckoutOptions", "Repository"))
+	} else if !isValidCodeCommitRepository(codeCommitCheckoutOptions.Repository) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*CodeCommitCheckoutOptions", codeCommitCheckoutOptions.Repository, "Repository"))
+	}
+	validationErrors = append(validationErrors, validateBranchAndRef(codeCommitCheckoutOptions.Branch, codeCommitCheckoutOptions.Ref, "*CodeCommitCheckoutOptions")...)
+	if codeCommitCheckoutOptions.CommitID != "" && !isValidCommitID(codeCommitCheckoutOptions.CommitID) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*CodeCommitCheckoutOptions", codeCommitCheckoutOptions.CommitID, "CommitID"))
+	}
+	if codeCommitCheckoutOptions.SecurityOptions != nil && codeCommitCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeSsh {
+		if codeCommitCheckoutOptions.SSHKeyID == "" {
+			validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*CodeCommitCheckoutOptions", "SSHKeyID"))
 		}
+	} else if codeCommitCheckoutOptions.SSHKeyID != "" {
+		validationErrors = append(validationErrors, newValidationErrorFieldShouldNotBeSet("*CodeCommitCheckoutOptions", "SSHKeyID"))
 	}
-	return nil
+	validationErrors = append(validationErrors, validateSparsePaths(codeCommitCheckoutOptions.SparsePaths, "*CodeCommitCheckoutOptions")...)
+	if codeCommitCheckoutOptions.CloneFilter != "" && !isValidCloneFilter(codeCommitCheckoutOptions.CloneFilter) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*CodeCommitCheckoutOptions", codeCommitCheckoutOptions.CloneFilter, "CloneFilter"))
+	}
+	if codeCommitCheckoutOptions.Submodules != nil {
+		validationErrors = append(validationErrors, validateSubmoduleOptions(codeCommitCheckoutOptions.Submodules, "*CodeCommitCheckoutOptions")...)
+	}
+	if codeCommitCheckoutOptions.LFS != nil {
+		validationErrors = append(validationErrors, validateLFSOptions(codeCommitCheckoutOptions.LFS, "*CodeCommitCheckoutOptions")...)
+	}
+	if codeCommitCheckoutOptions.SecurityOptions != nil {
+		validationErrors = append(validationErrors, validateSecurityOptions(codeCommitCheckoutOptions.SecurityOptions, "*CodeCommitCheckoutOptions", CheckoutOptionsTypeCodeCommit, SecurityOptionsTypeSsh, SecurityOptionsTypeAwsAccessKey)...)
+	}
+	return validationErrors.errorOrNil()
+}
+
+func validateSvnCheckoutOptions(svnCheckoutOptions *SvnCheckoutOptions) error {
+	var validationErrors ValidationErrors
+	if svnCheckoutOptions.URL == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*SvnCheckoutOptions", "URL"))
+	}
+	if svnCheckoutOptions.Revision == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*SvnCheckoutOptions", "Revision"))
+	}
+	if svnCheckoutOptions.Password != "" && svnCheckoutOptions.Username == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*SvnCheckoutOptions", "Username"))
+	}
+	if svnCheckoutOptions.SecurityOptions != nil {
+		validationErrors = append(validationErrors, validateSecurityOptions(svnCheckoutOptions.SecurityOptions, "*SvnCheckoutOptions", CheckoutOptionsTypeSvn, SecurityOptionsTypeSsh)...)
+	}
+	return validationErrors.errorOrNil()
+}
+
+func validateFossilCheckoutOptions(fossilCheckoutOptions *FossilCheckoutOptions) error {
+	var validationErrors ValidationErrors
+	if fossilCheckoutOptions.URL == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*FossilCheckoutOptions", "URL"))
+	}
+	if fossilCheckoutOptions.CheckinID == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*FossilCheckoutOptions", "CheckinID"))
+	}
+	if fossilCheckoutOptions.SecurityOptions != nil {
+		validationErrors = append(validationErrors, validateSecurityOptions(fossilCheckoutOptions.SecurityOptions, "*FossilCheckoutOptions", CheckoutOptionsTypeFossil, SecurityOptionsTypeSsh)...)
+	}
+	return validationErrors.errorOrNil()
+}
+
+func validateLocalCheckoutOptions(localCheckoutOptions *LocalCheckoutOptions) error {
+	var validationErrors ValidationErrors
+	if localCheckoutOptions.Path == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing("*LocalCheckoutOptions", "Path"))
+	}
+	validationErrors = append(validationErrors, validateBranchAndRef(localCheckoutOptions.Branch, localCheckoutOptions.Ref, "*LocalCheckoutOptions")...)
+	if localCheckoutOptions.CommitID != "" && !isValidCommitID(localCheckoutOptions.CommitID) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*LocalCheckoutOptions", localCheckoutOptions.CommitID, "CommitID"))
+	}
+	validationErrors = append(validationErrors, validateSparsePaths(localCheckoutOptions.SparsePaths, "*LocalCheckoutOptions")...)
+	if localCheckoutOptions.CloneFilter != "" && !isValidCloneFilter(localCheckoutOptions.CloneFilter) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat("*LocalCheckoutOptions", localCheckoutOptions.CloneFilter, "CloneFilter"))
+	}
+	if localCheckoutOptions.Submodules != nil {
+		validationErrors = append(validationErrors, validateSubmoduleOptions(localCheckoutOptions.Submodules, "*LocalCheckoutOptions")...)
+	}
+	if localCheckoutOptions.LFS != nil {
+		validationErrors = append(validationErrors, validateLFSOptions(localCheckoutOptions.LFS, "*LocalCheckoutOptions")...)
+	}
+	return validationErrors.errorOrNil()
+}
+
+func validateSparsePaths(sparsePaths []string, objectType string) ValidationErrors {
+	var validationErrors ValidationErrors
+	for _, sparsePath := range sparsePaths {
+		if !isValidSparsePath(sparsePath) {
+			validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat(objectType, sparsePath, "SparsePaths"))
+		}
+	}
+	return validationErrors
+}
+
+func validateSubmoduleOptions(submoduleOptions *SubmoduleOptions, objectType string) ValidationErrors {
+	var validationErrors ValidationErrors
+	if submoduleOptions.Disabled {
+		for _, field := range []struct {
+			name  string
+			isSet bool
+		}{
+			{"Paths", len(submoduleOptions.Paths) > 0},
+			{"Shallow", submoduleOptions.Shallow},
+			{"Depth", submoduleOptions.Depth != 0},
+			{"Jobs", submoduleOptions.Jobs != 0},
+		} {
+			if field.isSet {
+				validationErrors = append(validationErrors, newValidationErrorFieldShouldNotBeSet(objectType, "Submodules", field.name))
+			}
+		}
+	}
+	for _, path := range submoduleOptions.Paths {
+		if !isValidSubmodulePath(path) {
+			validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat(objectType, path, "Submodules", "Paths"))
+		}
+	}
+	if submoduleOptions.Depth < 0 {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat(objectType, strconv.Itoa(submoduleOptions.Depth), "Submodules", "Depth"))
+	}
+	if submoduleOptions.Jobs < 0 {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat(objectType, strconv.Itoa(submoduleOptions.Jobs), "Submodules", "Jobs"))
+	}
+	return validationErrors
+}
+
+// validateBranchAndRef checks that exactly one of branch and ref is set.
+func validateBranchAndRef(branch string, ref string, objectType string) ValidationErrors {
+	var validationErrors ValidationErrors
+	if branch == "" && ref == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing(objectType, "Branch"))
+	} else if branch != "" && ref != "" {
+		validationErrors = append(validationErrors, newValidationErrorFieldShouldNotBeSet(objectType, "Ref"))
+	}
+	if branch != "" && !isValidBranchName(branch) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat(objectType, branch, "Branch"))
+	}
+	if ref != "" && !isValidRef(ref) {
+		validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat(objectType, ref, "Ref"))
+	}
+	return validationErrors
+}
+
+func validateLFSOptions(lfsOptions *LFSOptions, objectType string) ValidationErrors {
+	var validationErrors ValidationErrors
+	for _, include := range lfsOptions.Include {
+		if !isValidLFSPattern(include) {
+			validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat(objectType, include, "LFS", "Include"))
+		}
+	}
+	for _, exclude := range lfsOptions.Exclude {
+		if !isValidLFSPattern(exclude) {
+			validationErrors = append(validationErrors, newValidationErrorInvalidFieldFormat(objectType, exclude, "LFS", "Exclude"))
+		}
+	}
+	return validationErrors
 }
 
-func validateSecurityOptions(securityOptions SecurityOptions, checkoutType CheckoutOptionsType, allowedTypes ...SecurityOptionsType) error {
+func validateSecurityOptions(securityOptions SecurityOptions, objectType string, checkoutType CheckoutOptionsType, allowedTypes ...SecurityOptionsType) ValidationErrors {
 	if !isAllowedSecurityOptionsType(securityOptions.Type(), allowedTypes) {
-		return newValidationErrorSecurityNotImplementedForCheckoutOptionsType(securityOptions.Type().String(), checkoutType.String())
+		return ValidationErrors{newValidationErrorSecurityNotImplementedForCheckoutOptionsType(objectType, securityOptions.Type().String(), checkoutType.String())}
 	}
-	return SecurityOptionsSwitch(
+	var validationErrors ValidationErrors
+	// the type was checked against allowedTypes above, so the switch cannot fail
+	_ = SecurityOptionsSwitch(
 		securityOptions,
-		validateSSHSecurityOptions,
-		validateAccessTokenSecurityOptions,
+		func(sshSecurityOptions *SSHSecurityOptions) error {
+			validationErrors = validateSSHSecurityOptions(sshSecurityOptions, objectType)
+			return nil
+		},
+		func(accessTokenSecurityOptions *AccessTokenSecurityOptions) error {
+			validationErrors = validateAccessTokenSecurityOptions(accessTokenSecurityOptions, objectType)
+			return nil
+		},
+		func(awsAccessKeySecurityOptions *AWSAccessKeySecurityOptions) error {
+			validationErrors = validateAWSAccessKeySecurityOptions(awsAccessKeySecurityOptions, objectType)
+			return nil
+		},
 	)
+	return validationErrors
 }
 
-func validateSSHSecurityOptions(sshSecurityOptions *SSHSecurityOptions) error {
+func validateSSHSecurityOptions(sshSecurityOptions *SSHSecurityOptions, objectType string) ValidationErrors {
 	return nil
 }
 
-func validateAccessTokenSecurityOptions(accessTokenSecurityOptions *AccessTokenSecurityOptions) error {
+func validateAccessTokenSecurityOptions(accessTokenSecurityOptions *AccessTokenSecurityOptions, objectType string) ValidationErrors {
+	var validationErrors ValidationErrors
 	if accessTokenSecurityOptions.AccessToken == "" {
-		return newValidationErrorRequiredFieldMissing("AccessTokenSecurityOptions", "AccessToken")
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing(objectType, "SecurityOptions", "AccessToken"))
 	}
-	return nil
+	return validationErrors
+}
+
+func validateAWSAccessKeySecurityOptions(awsAccessKeySecurityOptions *AWSAccessKeySecurityOptions, objectType string) ValidationErrors {
+	var validationErrors ValidationErrors
+	if awsAccessKeySecurityOptions.AccessKeyID == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing(objectType, "SecurityOptions", "AccessKeyID"))
+	}
+	if awsAccessKeySecurityOptions.SecretAccessKey == "" {
+		validationErrors = append(validationErrors, newValidationErrorRequiredFieldMissing(objectType, "SecurityOptions", "SecretAccessKey"))
+	}
+	return validationErrors
 }
 
 func isAllowedSecurityOptionsType(securityType SecurityOptionsType, allowedTypes []SecurityOptionsType) bool {
@@ -1118,6 +2952,10 @@ func newValidationErrorFieldShouldNotBeSet(objectType string, fieldPath ...strin
 	return newValidationError(ValidationErrorTypeFieldShouldNotBeSet, map[string]string{"type": objectType, "fieldPath": strings.Join(fieldPath, ".")})
 }
 
-func newValidationErrorSecurityNotImplementedForCheckoutOptionsType(securityType string, checkoutType string) ValidationError {
-	return newValidationError(ValidationErrorTypeSecurityNotImplementedForCheckoutOptionsType, map[string]string{"securityType": securityType, "checkoutType": checkoutType})
+func newValidationErrorInvalidFieldFormat(objectType string, value string, fieldPath ...string) ValidationError {
+	return newValidationError(ValidationErrorTypeInvalidFieldFormat, map[string]string{"type": objectType, "fieldPath": strings.Join(fieldPath, "."), "value": value})
+}
+
+func newValidationErrorSecurityNotImplementedForCheckoutOptionsType(objectType string, securityType string, checkoutType string) ValidationError {
+	return newValidationError(ValidationErrorTypeSecurityNotImplementedForCheckoutOptionsType, map[string]string{"type": objectType, "fieldPath": "SecurityOptions", "securityType": securityType, "checkoutType": checkoutType})
 }
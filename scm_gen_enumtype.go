@@ -8,21 +8,25 @@ type SecurityOptionsType uint
 
 var SecurityOptionsTypeSsh SecurityOptionsType = 0
 var SecurityOptionsTypeAccessToken SecurityOptionsType = 1
+var SecurityOptionsTypeAwsAccessKey SecurityOptionsType = 2
 
 var securityOptionsTypeToString = map[SecurityOptionsType]string{
 	SecurityOptionsTypeSsh: "ssh",
 	SecurityOptionsTypeAccessToken: "accessToken",
+	SecurityOptionsTypeAwsAccessKey: "awsAccessKey",
 }
 
 var stringToSecurityOptionsType = map[string]SecurityOptionsType{
 	"ssh": SecurityOptionsTypeSsh,
 	"accessToken": SecurityOptionsTypeAccessToken,
+	"awsAccessKey": SecurityOptionsTypeAwsAccessKey,
 }
 
 func AllSecurityOptionsTypes() []SecurityOptionsType {
 	return []SecurityOptionsType{
 		SecurityOptionsTypeSsh,
 		SecurityOptionsTypeAccessToken,
+		SecurityOptionsTypeAwsAccessKey,
 	}
 }
 
@@ -53,16 +57,23 @@ func (this *AccessTokenSecurityOptions) Type() SecurityOptionsType {
 	return SecurityOptionsTypeAccessToken
 }
 
+func (this *AWSAccessKeySecurityOptions) Type() SecurityOptionsType {
+	return SecurityOptionsTypeAwsAccessKey
+}
+
 func SecurityOptionsSwitch(
 	securityOptions SecurityOptions,
 	sSHSecurityOptionsFunc func(sSHSecurityOptions *SSHSecurityOptions) error,
 	accessTokenSecurityOptionsFunc func(accessTokenSecurityOptions *AccessTokenSecurityOptions) error,
+	aWSAccessKeySecurityOptionsFunc func(aWSAccessKeySecurityOptions *AWSAccessKeySecurityOptions) error,
 ) error {
 	switch securityOptions.Type() {
 	case SecurityOptionsTypeSsh:
 		return sSHSecurityOptionsFunc(securityOptions.(*SSHSecurityOptions))
 	case SecurityOptionsTypeAccessToken:
 		return accessTokenSecurityOptionsFunc(securityOptions.(*AccessTokenSecurityOptions))
+	case SecurityOptionsTypeAwsAccessKey:
+		return aWSAccessKeySecurityOptionsFunc(securityOptions.(*AWSAccessKeySecurityOptions))
 	default:
 		return newErrorUnknownSecurityOptionsType(securityOptions.Type())
 	}
@@ -71,12 +82,15 @@ func SecurityOptionsSwitch(
 func (this SecurityOptionsType) NewSecurityOptions(
 	sSHSecurityOptionsFunc func() (*SSHSecurityOptions, error),
 	accessTokenSecurityOptionsFunc func() (*AccessTokenSecurityOptions, error),
+	aWSAccessKeySecurityOptionsFunc func() (*AWSAccessKeySecurityOptions, error),
 ) (SecurityOptions, error) {
 	switch this {
 	case SecurityOptionsTypeSsh:
 		return sSHSecurityOptionsFunc()
 	case SecurityOptionsTypeAccessToken:
 		return accessTokenSecurityOptionsFunc()
+	case SecurityOptionsTypeAwsAccessKey:
+		return aWSAccessKeySecurityOptionsFunc()
 	default:
 		return nil, newErrorUnknownSecurityOptionsType(this)
 	}
@@ -85,12 +99,15 @@ func (this SecurityOptionsType) NewSecurityOptions(
 func (this SecurityOptionsType) Produce(
 	securityOptionsTypeSshFunc func() (interface{}, error),
 	securityOptionsTypeAccessTokenFunc func() (interface{}, error),
+	securityOptionsTypeAwsAccessKeyFunc func() (interface{}, error),
 ) (interface{}, error) {
 	switch this {
 	case SecurityOptionsTypeSsh:
 		return securityOptionsTypeSshFunc()
 	case SecurityOptionsTypeAccessToken:
 		return securityOptionsTypeAccessTokenFunc()
+	case SecurityOptionsTypeAwsAccessKey:
+		return securityOptionsTypeAwsAccessKeyFunc()
 	default:
 		return nil, newErrorUnknownSecurityOptionsType(this)
 	}
@@ -99,12 +116,15 @@ func (this SecurityOptionsType) Produce(
 func (this SecurityOptionsType) Handle(
 	securityOptionsTypeSshFunc func() error,
 	securityOptionsTypeAccessTokenFunc func() error,
+	securityOptionsTypeAwsAccessKeyFunc func() error,
 ) error {
 	switch this {
 	case SecurityOptionsTypeSsh:
 		return securityOptionsTypeSshFunc()
 	case SecurityOptionsTypeAccessToken:
 		return securityOptionsTypeAccessTokenFunc()
+	case SecurityOptionsTypeAwsAccessKey:
+		return securityOptionsTypeAwsAccessKeyFunc()
 	default:
 		return newErrorUnknownSecurityOptionsType(this)
 	}
@@ -121,6 +141,12 @@ var CheckoutOptionsTypeHg CheckoutOptionsType = 2
 var CheckoutOptionsTypeBitbucketGit CheckoutOptionsType = 3
 var CheckoutOptionsTypeBitbucketHg CheckoutOptionsType = 4
 var CheckoutOptionsTypeGitlab CheckoutOptionsType = 5
+var CheckoutOptionsTypeGitea CheckoutOptionsType = 6
+var CheckoutOptionsTypeAzureDevOps CheckoutOptionsType = 7
+var CheckoutOptionsTypeCodeCommit CheckoutOptionsType = 8
+var CheckoutOptionsTypeSvn CheckoutOptionsType = 9
+var CheckoutOptionsTypeFossil CheckoutOptionsType = 10
+var CheckoutOptionsTypeLocal CheckoutOptionsType = 11
 
 var checkoutOptionsTypeToString = map[CheckoutOptionsType]string{
 	CheckoutOptionsTypeGit: "git",
@@ -129,6 +155,12 @@ var checkoutOptionsTypeToString = map[CheckoutOptionsType]string{
 	CheckoutOptionsTypeBitbucketGit: "bitbucketGit",
 	CheckoutOptionsTypeBitbucketHg: "bitbucketHg",
 	CheckoutOptionsTypeGitlab: "gitlab",
+	CheckoutOptionsTypeGitea: "gitea",
+	CheckoutOptionsTypeAzureDevOps: "azureDevOps",
+	CheckoutOptionsTypeCodeCommit: "codeCommit",
+	CheckoutOptionsTypeSvn: "svn",
+	CheckoutOptionsTypeFossil: "fossil",
+	CheckoutOptionsTypeLocal: "local",
 }
 
 var stringToCheckoutOptionsType = map[string]CheckoutOptionsType{
@@ -138,6 +170,12 @@ var stringToCheckoutOptionsType = map[string]CheckoutOptionsType{
 	"bitbucketGit": CheckoutOptionsTypeBitbucketGit,
 	"bitbucketHg": CheckoutOptionsTypeBitbucketHg,
 	"gitlab": CheckoutOptionsTypeGitlab,
+	"gitea": CheckoutOptionsTypeGitea,
+	"azureDevOps": CheckoutOptionsTypeAzureDevOps,
+	"codeCommit": CheckoutOptionsTypeCodeCommit,
+	"svn": CheckoutOptionsTypeSvn,
+	"fossil": CheckoutOptionsTypeFossil,
+	"local": CheckoutOptionsTypeLocal,
 }
 
 func AllCheckoutOptionsTypes() []CheckoutOptionsType {
@@ -148,6 +186,12 @@ func AllCheckoutOptionsTypes() []CheckoutOptionsType {
 		CheckoutOptionsTypeBitbucketGit,
 		CheckoutOptionsTypeBitbucketHg,
 		CheckoutOptionsTypeGitlab,
+		CheckoutOptionsTypeGitea,
+		CheckoutOptionsTypeAzureDevOps,
+		CheckoutOptionsTypeCodeCommit,
+		CheckoutOptionsTypeSvn,
+		CheckoutOptionsTypeFossil,
+		CheckoutOptionsTypeLocal,
 	}
 }
 
@@ -194,6 +238,30 @@ func (this *GitlabCheckoutOptions) Type() CheckoutOptionsType {
 	return CheckoutOptionsTypeGitlab
 }
 
+func (this *GiteaCheckoutOptions) Type() CheckoutOptionsType {
+	return CheckoutOptionsTypeGitea
+}
+
+func (this *AzureDevOpsCheckoutOptions) Type() CheckoutOptionsType {
+	return CheckoutOptionsTypeAzureDevOps
+}
+
+func (this *CodeCommitCheckoutOptions) Type() CheckoutOptionsType {
+	return CheckoutOptionsTypeCodeCommit
+}
+
+func (this *SvnCheckoutOptions) Type() CheckoutOptionsType {
+	return CheckoutOptionsTypeSvn
+}
+
+func (this *FossilCheckoutOptions) Type() CheckoutOptionsType {
+	return CheckoutOptionsTypeFossil
+}
+
+func (this *LocalCheckoutOptions) Type() CheckoutOptionsType {
+	return CheckoutOptionsTypeLocal
+}
+
 func CheckoutOptionsSwitch(
 	checkoutOptions CheckoutOptions,
 	gitCheckoutOptionsFunc func(gitCheckoutOptions *GitCheckoutOptions) error,
@@ -202,6 +270,12 @@ func CheckoutOptionsSwitch(
 	bitbucketGitCheckoutOptionsFunc func(bitbucketGitCheckoutOptions *BitbucketGitCheckoutOptions) error,
 	bitbucketHgCheckoutOptionsFunc func(bitbucketHgCheckoutOptions *BitbucketHgCheckoutOptions) error,
 	gitlabCheckoutOptionsFunc func(gitlabCheckoutOptions *GitlabCheckoutOptions) error,
+	giteaCheckoutOptionsFunc func(giteaCheckoutOptions *GiteaCheckoutOptions) error,
+	azureDevOpsCheckoutOptionsFunc func(azureDevOpsCheckoutOptions *AzureDevOpsCheckoutOptions) error,
+	codeCommitCheckoutOptionsFunc func(codeCommitCheckoutOptions *CodeCommitCheckoutOptions) error,
+	svnCheckoutOptionsFunc func(svnCheckoutOptions *SvnCheckoutOptions) error,
+	fossilCheckoutOptionsFunc func(fossilCheckoutOptions *FossilCheckoutOptions) error,
+	localCheckoutOptionsFunc func(localCheckoutOptions *LocalCheckoutOptions) error,
 ) error {
 	switch checkoutOptions.Type() {
 	case CheckoutOptionsTypeGit:
@@ -216,6 +290,18 @@ func CheckoutOptionsSwitch(
 		return bitbucketHgCheckoutOptionsFunc(checkoutOptions.(*BitbucketHgCheckoutOptions))
 	case CheckoutOptionsTypeGitlab:
 		return gitlabCheckoutOptionsFunc(checkoutOptions.(*GitlabCheckoutOptions))
+	case CheckoutOptionsTypeGitea:
+		return giteaCheckoutOptionsFunc(checkoutOptions.(*GiteaCheckoutOptions))
+	case CheckoutOptionsTypeAzureDevOps:
+		return azureDevOpsCheckoutOptionsFunc(checkoutOptions.(*AzureDevOpsCheckoutOptions))
+	case CheckoutOptionsTypeCodeCommit:
+		return codeCommitCheckoutOptionsFunc(checkoutOptions.(*CodeCommitCheckoutOptions))
+	case CheckoutOptionsTypeSvn:
+		return svnCheckoutOptionsFunc(checkoutOptions.(*SvnCheckoutOptions))
+	case CheckoutOptionsTypeFossil:
+		return fossilCheckoutOptionsFunc(checkoutOptions.(*FossilCheckoutOptions))
+	case CheckoutOptionsTypeLocal:
+		return localCheckoutOptionsFunc(checkoutOptions.(*LocalCheckoutOptions))
 	default:
 		return newErrorUnknownCheckoutOptionsType(checkoutOptions.Type())
 	}
@@ -228,6 +314,12 @@ func (this CheckoutOptionsType) NewCheckoutOptions(
 	bitbucketGitCheckoutOptionsFunc func() (*BitbucketGitCheckoutOptions, error),
 	bitbucketHgCheckoutOptionsFunc func() (*BitbucketHgCheckoutOptions, error),
 	gitlabCheckoutOptionsFunc func() (*GitlabCheckoutOptions, error),
+	giteaCheckoutOptionsFunc func() (*GiteaCheckoutOptions, error),
+	azureDevOpsCheckoutOptionsFunc func() (*AzureDevOpsCheckoutOptions, error),
+	codeCommitCheckoutOptionsFunc func() (*CodeCommitCheckoutOptions, error),
+	svnCheckoutOptionsFunc func() (*SvnCheckoutOptions, error),
+	fossilCheckoutOptionsFunc func() (*FossilCheckoutOptions, error),
+	localCheckoutOptionsFunc func() (*LocalCheckoutOptions, error),
 ) (CheckoutOptions, error) {
 	switch this {
 	case CheckoutOptionsTypeGit:
@@ -242,6 +334,18 @@ func (this CheckoutOptionsType) NewCheckoutOptions(
 		return bitbucketHgCheckoutOptionsFunc()
 	case CheckoutOptionsTypeGitlab:
 		return gitlabCheckoutOptionsFunc()
+	case CheckoutOptionsTypeGitea:
+		return giteaCheckoutOptionsFunc()
+	case CheckoutOptionsTypeAzureDevOps:
+		return azureDevOpsCheckoutOptionsFunc()
+	case CheckoutOptionsTypeCodeCommit:
+		return codeCommitCheckoutOptionsFunc()
+	case CheckoutOptionsTypeSvn:
+		return svnCheckoutOptionsFunc()
+	case CheckoutOptionsTypeFossil:
+		return fossilCheckoutOptionsFunc()
+	case CheckoutOptionsTypeLocal:
+		return localCheckoutOptionsFunc()
 	default:
 		return nil, newErrorUnknownCheckoutOptionsType(this)
 	}
@@ -254,6 +358,12 @@ func (this CheckoutOptionsType) Produce(
 	checkoutOptionsTypeBitbucketGitFunc func() (interface{}, error),
 	checkoutOptionsTypeBitbucketHgFunc func() (interface{}, error),
 	checkoutOptionsTypeGitlabFunc func() (interface{}, error),
+	checkoutOptionsTypeGiteaFunc func() (interface{}, error),
+	checkoutOptionsTypeAzureDevOpsFunc func() (interface{}, error),
+	checkoutOptionsTypeCodeCommitFunc func() (interface{}, error),
+	checkoutOptionsTypeSvnFunc func() (interface{}, error),
+	checkoutOptionsTypeFossilFunc func() (interface{}, error),
+	checkoutOptionsTypeLocalFunc func() (interface{}, error),
 ) (interface{}, error) {
 	switch this {
 	case CheckoutOptionsTypeGit:
@@ -268,6 +378,18 @@ func (this CheckoutOptionsType) Produce(
 		return checkoutOptionsTypeBitbucketHgFunc()
 	case CheckoutOptionsTypeGitlab:
 		return checkoutOptionsTypeGitlabFunc()
+	case CheckoutOptionsTypeGitea:
+		return checkoutOptionsTypeGiteaFunc()
+	case CheckoutOptionsTypeAzureDevOps:
+		return checkoutOptionsTypeAzureDevOpsFunc()
+	case CheckoutOptionsTypeCodeCommit:
+		return checkoutOptionsTypeCodeCommitFunc()
+	case CheckoutOptionsTypeSvn:
+		return checkoutOptionsTypeSvnFunc()
+	case CheckoutOptionsTypeFossil:
+		return checkoutOptionsTypeFossilFunc()
+	case CheckoutOptionsTypeLocal:
+		return checkoutOptionsTypeLocalFunc()
 	default:
 		return nil, newErrorUnknownCheckoutOptionsType(this)
 	}
@@ -280,6 +402,12 @@ func (this CheckoutOptionsType) Handle(
 	checkoutOptionsTypeBitbucketGitFunc func() error,
 	checkoutOptionsTypeBitbucketHgFunc func() error,
 	checkoutOptionsTypeGitlabFunc func() error,
+	checkoutOptionsTypeGiteaFunc func() error,
+	checkoutOptionsTypeAzureDevOpsFunc func() error,
+	checkoutOptionsTypeCodeCommitFunc func() error,
+	checkoutOptionsTypeSvnFunc func() error,
+	checkoutOptionsTypeFossilFunc func() error,
+	checkoutOptionsTypeLocalFunc func() error,
 ) error {
 	switch this {
 	case CheckoutOptionsTypeGit:
@@ -294,6 +422,18 @@ func (this CheckoutOptionsType) Handle(
 		return checkoutOptionsTypeBitbucketHgFunc()
 	case CheckoutOptionsTypeGitlab:
 		return checkoutOptionsTypeGitlabFunc()
+	case CheckoutOptionsTypeGitea:
+		return checkoutOptionsTypeGiteaFunc()
+	case CheckoutOptionsTypeAzureDevOps:
+		return checkoutOptionsTypeAzureDevOpsFunc()
+	case CheckoutOptionsTypeCodeCommit:
+		return checkoutOptionsTypeCodeCommitFunc()
+	case CheckoutOptionsTypeSvn:
+		return checkoutOptionsTypeSvnFunc()
+	case CheckoutOptionsTypeFossil:
+		return checkoutOptionsTypeFossilFunc()
+	case CheckoutOptionsTypeLocal:
+		return checkoutOptionsTypeLocalFunc()
 	default:
 		return newErrorUnknownCheckoutOptionsType(this)
 	}
@@ -0,0 +1,719 @@
+// Package scmpb contains the protobuf messages for the CheckoutOptions and
+// SecurityOptions of github.com/peter-edge/go-scm, and converters to and from them.
+package scmpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative scm.proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/peter-edge/go-scm"
+)
+
+var (
+	errorCheckoutOptionsNotSet = errors.New("scmpb: CheckoutOptionsNotSet")
+	errorSecurityOptionsNotSet = errors.New("scmpb: SecurityOptionsNotSet")
+)
+
+// FromCheckoutOptions converts checkoutOptions to its protobuf message.
+// An SSH private key is read to the end.
+func FromCheckoutOptions(checkoutOptions scm.CheckoutOptions) (*CheckoutOptions, error) {
+	return fromCheckoutOptions(checkoutOptions)
+}
+
+// ToCheckoutOptions converts a protobuf message to CheckoutOptions.
+// The result is not validated; use scm.Validate.
+func ToCheckoutOptions(checkoutOptions *CheckoutOptions) (scm.CheckoutOptions, error) {
+	return toCheckoutOptions(checkoutOptions)
+}
+
+// FromSecurityOptions converts securityOptions to its protobuf message.
+// A nil securityOptions converts to a nil message.
+func FromSecurityOptions(securityOptions scm.SecurityOptions) (*SecurityOptions, error) {
+	return fromSecurityOptions(securityOptions)
+}
+
+// ToSecurityOptions converts a protobuf message to SecurityOptions.
+// A nil message converts to nil.
+func ToSecurityOptions(securityOptions *SecurityOptions) (scm.SecurityOptions, error) {
+	return toSecurityOptions(securityOptions)
+}
+
+// ***** PRIVATE *****
+
+func fromCheckoutOptions(checkoutOptions scm.CheckoutOptions) (*CheckoutOptions, error) {
+	var protoCheckoutOptions *CheckoutOptions
+	if err := scm.CheckoutOptionsSwitch(
+		checkoutOptions,
+		func(gitCheckoutOptions *scm.GitCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(gitCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_Git{
+					Git: &GitCheckoutOptions{
+						User:              gitCheckoutOptions.User,
+						CommitterName:     gitCheckoutOptions.CommitterName,
+						CommitterUsername: gitCheckoutOptions.CommitterUsername,
+						Email:             gitCheckoutOptions.Email,
+						Host:              gitCheckoutOptions.Host,
+						Path:              gitCheckoutOptions.Path,
+						Branch:            gitCheckoutOptions.Branch,
+						Ref:               gitCheckoutOptions.Ref,
+						CommitId:          gitCheckoutOptions.CommitID,
+						CommitMessage:     gitCheckoutOptions.CommitMessage,
+						SparsePaths:       gitCheckoutOptions.SparsePaths,
+						CloneFilter:       gitCheckoutOptions.CloneFilter,
+						Submodules:        fromSubmoduleOptions(gitCheckoutOptions.Submodules),
+						Lfs:               fromLFSOptions(gitCheckoutOptions.LFS),
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(githubCheckoutOptions *scm.GithubCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(githubCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_Github{
+					Github: &GithubCheckoutOptions{
+						User:              githubCheckoutOptions.User,
+						CommitterName:     githubCheckoutOptions.CommitterName,
+						CommitterUsername: githubCheckoutOptions.CommitterUsername,
+						Email:             githubCheckoutOptions.Email,
+						Repository:        githubCheckoutOptions.Repository,
+						Branch:            githubCheckoutOptions.Branch,
+						Ref:               githubCheckoutOptions.Ref,
+						CommitId:          githubCheckoutOptions.CommitID,
+						CommitMessage:     githubCheckoutOptions.CommitMessage,
+						SparsePaths:       githubCheckoutOptions.SparsePaths,
+						CloneFilter:       githubCheckoutOptions.CloneFilter,
+						Submodules:        fromSubmoduleOptions(githubCheckoutOptions.Submodules),
+						Lfs:               fromLFSOptions(githubCheckoutOptions.LFS),
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(hgCheckoutOptions *scm.HgCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(hgCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_Hg{
+					Hg: &HgCheckoutOptions{
+						User:              hgCheckoutOptions.User,
+						CommitterName:     hgCheckoutOptions.CommitterName,
+						CommitterUsername: hgCheckoutOptions.CommitterUsername,
+						Email:             hgCheckoutOptions.Email,
+						Host:              hgCheckoutOptions.Host,
+						Path:              hgCheckoutOptions.Path,
+						ChangesetId:       hgCheckoutOptions.ChangesetID,
+						CommitMessage:     hgCheckoutOptions.CommitMessage,
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(bitbucketGitCheckoutOptions *scm.BitbucketGitCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(bitbucketGitCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_BitbucketGit{
+					BitbucketGit: &BitbucketGitCheckoutOptions{
+						User:              bitbucketGitCheckoutOptions.User,
+						CommitterName:     bitbucketGitCheckoutOptions.CommitterName,
+						CommitterUsername: bitbucketGitCheckoutOptions.CommitterUsername,
+						Email:             bitbucketGitCheckoutOptions.Email,
+						Repository:        bitbucketGitCheckoutOptions.Repository,
+						Branch:            bitbucketGitCheckoutOptions.Branch,
+						Ref:               bitbucketGitCheckoutOptions.Ref,
+						CommitId:          bitbucketGitCheckoutOptions.CommitID,
+						CommitMessage:     bitbucketGitCheckoutOptions.CommitMessage,
+						SparsePaths:       bitbucketGitCheckoutOptions.SparsePaths,
+						CloneFilter:       bitbucketGitCheckoutOptions.CloneFilter,
+						Submodules:        fromSubmoduleOptions(bitbucketGitCheckoutOptions.Submodules),
+						Lfs:               fromLFSOptions(bitbucketGitCheckoutOptions.LFS),
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(bitbucketHgCheckoutOptions *scm.BitbucketHgCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(bitbucketHgCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_BitbucketHg{
+					BitbucketHg: &BitbucketHgCheckoutOptions{
+						User:              bitbucketHgCheckoutOptions.User,
+						CommitterName:     bitbucketHgCheckoutOptions.CommitterName,
+						CommitterUsername: bitbucketHgCheckoutOptions.CommitterUsername,
+						Email:             bitbucketHgCheckoutOptions.Email,
+						Repository:        bitbucketHgCheckoutOptions.Repository,
+						ChangesetId:       bitbucketHgCheckoutOptions.ChangesetID,
+						CommitMessage:     bitbucketHgCheckoutOptions.CommitMessage,
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(gitlabCheckoutOptions *scm.GitlabCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(gitlabCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_Gitlab{
+					Gitlab: &GitlabCheckoutOptions{
+						User:              gitlabCheckoutOptions.User,
+						CommitterName:     gitlabCheckoutOptions.CommitterName,
+						CommitterUsername: gitlabCheckoutOptions.CommitterUsername,
+						Email:             gitlabCheckoutOptions.Email,
+						Repository:        gitlabCheckoutOptions.Repository,
+						Branch:            gitlabCheckoutOptions.Branch,
+						Ref:               gitlabCheckoutOptions.Ref,
+						CommitId:          gitlabCheckoutOptions.CommitID,
+						CommitMessage:     gitlabCheckoutOptions.CommitMessage,
+						SparsePaths:       gitlabCheckoutOptions.SparsePaths,
+						CloneFilter:       gitlabCheckoutOptions.CloneFilter,
+						Submodules:        fromSubmoduleOptions(gitlabCheckoutOptions.Submodules),
+						Lfs:               fromLFSOptions(gitlabCheckoutOptions.LFS),
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(giteaCheckoutOptions *scm.GiteaCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(giteaCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_Gitea{
+					Gitea: &GiteaCheckoutOptions{
+						User:              giteaCheckoutOptions.User,
+						CommitterName:     giteaCheckoutOptions.CommitterName,
+						CommitterUsername: giteaCheckoutOptions.CommitterUsername,
+						Email:             giteaCheckoutOptions.Email,
+						Host:              giteaCheckoutOptions.Host,
+						Repository:        giteaCheckoutOptions.Repository,
+						Branch:            giteaCheckoutOptions.Branch,
+						Ref:               giteaCheckoutOptions.Ref,
+						CommitId:          giteaCheckoutOptions.CommitID,
+						CommitMessage:     giteaCheckoutOptions.CommitMessage,
+						SparsePaths:       giteaCheckoutOptions.SparsePaths,
+						CloneFilter:       giteaCheckoutOptions.CloneFilter,
+						Submodules:        fromSubmoduleOptions(giteaCheckoutOptions.Submodules),
+						Lfs:               fromLFSOptions(giteaCheckoutOptions.LFS),
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(azureDevOpsCheckoutOptions *scm.AzureDevOpsCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(azureDevOpsCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_AzureDevOps{
+					AzureDevOps: &AzureDevOpsCheckoutOptions{
+						CommitterName:     azureDevOpsCheckoutOptions.CommitterName,
+						CommitterUsername: azureDevOpsCheckoutOptions.CommitterUsername,
+						Email:             azureDevOpsCheckoutOptions.Email,
+						Organization:      azureDevOpsCheckoutOptions.Organization,
+						Project:           azureDevOpsCheckoutOptions.Project,
+						Repository:        azureDevOpsCheckoutOptions.Repository,
+						Branch:            azureDevOpsCheckoutOptions.Branch,
+						Ref:               azureDevOpsCheckoutOptions.Ref,
+						CommitId:          azureDevOpsCheckoutOptions.CommitID,
+						CommitMessage:     azureDevOpsCheckoutOptions.CommitMessage,
+						SparsePaths:       azureDevOpsCheckoutOptions.SparsePaths,
+						CloneFilter:       azureDevOpsCheckoutOptions.CloneFilter,
+						Submodules:        fromSubmoduleOptions(azureDevOpsCheckoutOptions.Submodules),
+						Lfs:               fromLFSOptions(azureDevOpsCheckoutOptions.LFS),
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(codeCommitCheckoutOptions *scm.CodeCommitCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(codeCommitCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_CodeCommit{
+					CodeCommit: &CodeCommitCheckoutOptions{
+						CommitterName:     codeCommitCheckoutOptions.CommitterName,
+						CommitterUsername: codeCommitCheckoutOptions.CommitterUsername,
+						Email:             codeCommitCheckoutOptions.Email,
+						Region:            codeCommitCheckoutOptions.Region,
+						Repository:        codeCommitCheckoutOptions.Repository,
+						SshKeyId:          codeCommitCheckoutOptions.SSHKeyID,
+						Branch:            codeCommitCheckoutOptions.Branch,
+						Ref:               codeCommitCheckoutOptions.Ref,
+						CommitId:          codeCommitCheckoutOptions.CommitID,
+						CommitMessage:     codeCommitCheckoutOptions.CommitMessage,
+						SparsePaths:       codeCommitCheckoutOptions.SparsePaths,
+						CloneFilter:       codeCommitCheckoutOptions.CloneFilter,
+						Submodules:        fromSubmoduleOptions(codeCommitCheckoutOptions.Submodules),
+						Lfs:               fromLFSOptions(codeCommitCheckoutOptions.LFS),
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(svnCheckoutOptions *scm.SvnCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(svnCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_Svn{
+					Svn: &SvnCheckoutOptions{
+						CommitterName:     svnCheckoutOptions.CommitterName,
+						CommitterUsername: svnCheckoutOptions.CommitterUsername,
+						Email:             svnCheckoutOptions.Email,
+						Url:               svnCheckoutOptions.URL,
+						Path:              svnCheckoutOptions.Path,
+						Revision:          svnCheckoutOptions.Revision,
+						Username:          svnCheckoutOptions.Username,
+						Password:          svnCheckoutOptions.Password,
+						CommitMessage:     svnCheckoutOptions.CommitMessage,
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(fossilCheckoutOptions *scm.FossilCheckoutOptions) error {
+			securityOptions, err := fromSecurityOptions(fossilCheckoutOptions.SecurityOptions)
+			if err != nil {
+				return err
+			}
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_Fossil{
+					Fossil: &FossilCheckoutOptions{
+						CommitterName:     fossilCheckoutOptions.CommitterName,
+						CommitterUsername: fossilCheckoutOptions.CommitterUsername,
+						Email:             fossilCheckoutOptions.Email,
+						Url:               fossilCheckoutOptions.URL,
+						CheckinId:         fossilCheckoutOptions.CheckinID,
+						CommitMessage:     fossilCheckoutOptions.CommitMessage,
+						SecurityOptions:   securityOptions,
+					},
+				},
+			}
+			return nil
+		},
+		func(localCheckoutOptions *scm.LocalCheckoutOptions) error {
+			protoCheckoutOptions = &CheckoutOptions{
+				CheckoutOptions: &CheckoutOptions_Local{
+					Local: &LocalCheckoutOptions{
+						CommitterName:     localCheckoutOptions.CommitterName,
+						CommitterUsername: localCheckoutOptions.CommitterUsername,
+						Email:             localCheckoutOptions.Email,
+						Path:              localCheckoutOptions.Path,
+						Branch:            localCheckoutOptions.Branch,
+						Ref:               localCheckoutOptions.Ref,
+						CommitId:          localCheckoutOptions.CommitID,
+						CommitMessage:     localCheckoutOptions.CommitMessage,
+						SparsePaths:       localCheckoutOptions.SparsePaths,
+						CloneFilter:       localCheckoutOptions.CloneFilter,
+						Submodules:        fromSubmoduleOptions(localCheckoutOptions.Submodules),
+						Lfs:               fromLFSOptions(localCheckoutOptions.LFS),
+					},
+				},
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+	return protoCheckoutOptions, nil
+}
+
+func toCheckoutOptions(protoCheckoutOptions *CheckoutOptions) (scm.CheckoutOptions, error) {
+	switch checkoutOptions := protoCheckoutOptions.GetCheckoutOptions().(type) {
+	case *CheckoutOptions_Git:
+		securityOptions, err := toSecurityOptions(checkoutOptions.Git.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.GitCheckoutOptions{
+			User:              checkoutOptions.Git.GetUser(),
+			CommitterName:     checkoutOptions.Git.GetCommitterName(),
+			CommitterUsername: checkoutOptions.Git.GetCommitterUsername(),
+			Email:             checkoutOptions.Git.GetEmail(),
+			Host:              checkoutOptions.Git.GetHost(),
+			Path:              checkoutOptions.Git.GetPath(),
+			Branch:            checkoutOptions.Git.GetBranch(),
+			Ref:               checkoutOptions.Git.GetRef(),
+			CommitID:          checkoutOptions.Git.GetCommitId(),
+			CommitMessage:     checkoutOptions.Git.GetCommitMessage(),
+			SparsePaths:       checkoutOptions.Git.GetSparsePaths(),
+			CloneFilter:       checkoutOptions.Git.GetCloneFilter(),
+			Submodules:        toSubmoduleOptions(checkoutOptions.Git.GetSubmodules()),
+			LFS:               toLFSOptions(checkoutOptions.Git.GetLfs()),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_Github:
+		securityOptions, err := toSecurityOptions(checkoutOptions.Github.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.GithubCheckoutOptions{
+			User:              checkoutOptions.Github.GetUser(),
+			CommitterName:     checkoutOptions.Github.GetCommitterName(),
+			CommitterUsername: checkoutOptions.Github.GetCommitterUsername(),
+			Email:             checkoutOptions.Github.GetEmail(),
+			Repository:        checkoutOptions.Github.GetRepository(),
+			Branch:            checkoutOptions.Github.GetBranch(),
+			Ref:               checkoutOptions.Github.GetRef(),
+			CommitID:          checkoutOptions.Github.GetCommitId(),
+			CommitMessage:     checkoutOptions.Github.GetCommitMessage(),
+			SparsePaths:       checkoutOptions.Github.GetSparsePaths(),
+			CloneFilter:       checkoutOptions.Github.GetCloneFilter(),
+			Submodules:        toSubmoduleOptions(checkoutOptions.Github.GetSubmodules()),
+			LFS:               toLFSOptions(checkoutOptions.Github.GetLfs()),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_Hg:
+		securityOptions, err := toSecurityOptions(checkoutOptions.Hg.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.HgCheckoutOptions{
+			User:              checkoutOptions.Hg.GetUser(),
+			CommitterName:     checkoutOptions.Hg.GetCommitterName(),
+			CommitterUsername: checkoutOptions.Hg.GetCommitterUsername(),
+			Email:             checkoutOptions.Hg.GetEmail(),
+			Host:              checkoutOptions.Hg.GetHost(),
+			Path:              checkoutOptions.Hg.GetPath(),
+			ChangesetID:       checkoutOptions.Hg.GetChangesetId(),
+			CommitMessage:     checkoutOptions.Hg.GetCommitMessage(),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_BitbucketGit:
+		securityOptions, err := toSecurityOptions(checkoutOptions.BitbucketGit.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.BitbucketGitCheckoutOptions{
+			User:              checkoutOptions.BitbucketGit.GetUser(),
+			CommitterName:     checkoutOptions.BitbucketGit.GetCommitterName(),
+			CommitterUsername: checkoutOptions.BitbucketGit.GetCommitterUsername(),
+			Email:             checkoutOptions.BitbucketGit.GetEmail(),
+			Repository:        checkoutOptions.BitbucketGit.GetRepository(),
+			Branch:            checkoutOptions.BitbucketGit.GetBranch(),
+			Ref:               checkoutOptions.BitbucketGit.GetRef(),
+			CommitID:          checkoutOptions.BitbucketGit.GetCommitId(),
+			CommitMessage:     checkoutOptions.BitbucketGit.GetCommitMessage(),
+			SparsePaths:       checkoutOptions.BitbucketGit.GetSparsePaths(),
+			CloneFilter:       checkoutOptions.BitbucketGit.GetCloneFilter(),
+			Submodules:        toSubmoduleOptions(checkoutOptions.BitbucketGit.GetSubmodules()),
+			LFS:               toLFSOptions(checkoutOptions.BitbucketGit.GetLfs()),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_BitbucketHg:
+		securityOptions, err := toSecurityOptions(checkoutOptions.BitbucketHg.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.BitbucketHgCheckoutOptions{
+			User:              checkoutOptions.BitbucketHg.GetUser(),
+			CommitterName:     checkoutOptions.BitbucketHg.GetCommitterName(),
+			CommitterUsername: checkoutOptions.BitbucketHg.GetCommitterUsername(),
+			Email:             checkoutOptions.BitbucketHg.GetEmail(),
+			Repository:        checkoutOptions.BitbucketHg.GetRepository(),
+			ChangesetID:       checkoutOptions.BitbucketHg.GetChangesetId(),
+			CommitMessage:     checkoutOptions.BitbucketHg.GetCommitMessage(),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_Gitlab:
+		securityOptions, err := toSecurityOptions(checkoutOptions.Gitlab.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.GitlabCheckoutOptions{
+			User:              checkoutOptions.Gitlab.GetUser(),
+			CommitterName:     checkoutOptions.Gitlab.GetCommitterName(),
+			CommitterUsername: checkoutOptions.Gitlab.GetCommitterUsername(),
+			Email:             checkoutOptions.Gitlab.GetEmail(),
+			Repository:        checkoutOptions.Gitlab.GetRepository(),
+			Branch:            checkoutOptions.Gitlab.GetBranch(),
+			Ref:               checkoutOptions.Gitlab.GetRef(),
+			CommitID:          checkoutOptions.Gitlab.GetCommitId(),
+			CommitMessage:     checkoutOptions.Gitlab.GetCommitMessage(),
+			SparsePaths:       checkoutOptions.Gitlab.GetSparsePaths(),
+			CloneFilter:       checkoutOptions.Gitlab.GetCloneFilter(),
+			Submodules:        toSubmoduleOptions(checkoutOptions.Gitlab.GetSubmodules()),
+			LFS:               toLFSOptions(checkoutOptions.Gitlab.GetLfs()),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_Gitea:
+		securityOptions, err := toSecurityOptions(checkoutOptions.Gitea.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.GiteaCheckoutOptions{
+			User:              checkoutOptions.Gitea.GetUser(),
+			CommitterName:     checkoutOptions.Gitea.GetCommitterName(),
+			CommitterUsername: checkoutOptions.Gitea.GetCommitterUsername(),
+			Email:             checkoutOptions.Gitea.GetEmail(),
+			Host:              checkoutOptions.Gitea.GetHost(),
+			Repository:        checkoutOptions.Gitea.GetRepository(),
+			Branch:            checkoutOptions.Gitea.GetBranch(),
+			Ref:               checkoutOptions.Gitea.GetRef(),
+			CommitID:          checkoutOptions.Gitea.GetCommitId(),
+			CommitMessage:     checkoutOptions.Gitea.GetCommitMessage(),
+			SparsePaths:       checkoutOptions.Gitea.GetSparsePaths(),
+			CloneFilter:       checkoutOptions.Gitea.GetCloneFilter(),
+			Submodules:        toSubmoduleOptions(checkoutOptions.Gitea.GetSubmodules()),
+			LFS:               toLFSOptions(checkoutOptions.Gitea.GetLfs()),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_AzureDevOps:
+		securityOptions, err := toSecurityOptions(checkoutOptions.AzureDevOps.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.AzureDevOpsCheckoutOptions{
+			CommitterName:     checkoutOptions.AzureDevOps.GetCommitterName(),
+			CommitterUsername: checkoutOptions.AzureDevOps.GetCommitterUsername(),
+			Email:             checkoutOptions.AzureDevOps.GetEmail(),
+			Organization:      checkoutOptions.AzureDevOps.GetOrganization(),
+			Project:           checkoutOptions.AzureDevOps.GetProject(),
+			Repository:        checkoutOptions.AzureDevOps.GetRepository(),
+			Branch:            checkoutOptions.AzureDevOps.GetBranch(),
+			Ref:               checkoutOptions.AzureDevOps.GetRef(),
+			CommitID:          checkoutOptions.AzureDevOps.GetCommitId(),
+			CommitMessage:     checkoutOptions.AzureDevOps.GetCommitMessage(),
+			SparsePaths:       checkoutOptions.AzureDevOps.GetSparsePaths(),
+			CloneFilter:       checkoutOptions.AzureDevOps.GetCloneFilter(),
+			Submodules:        toSubmoduleOptions(checkoutOptions.AzureDevOps.GetSubmodules()),
+			LFS:               toLFSOptions(checkoutOptions.AzureDevOps.GetLfs()),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_CodeCommit:
+		securityOptions, err := toSecurityOptions(checkoutOptions.CodeCommit.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.CodeCommitCheckoutOptions{
+			CommitterName:     checkoutOptions.CodeCommit.GetCommitterName(),
+			CommitterUsername: checkoutOptions.CodeCommit.GetCommitterUsername(),
+			Email:             checkoutOptions.CodeCommit.GetEmail(),
+			Region:            checkoutOptions.CodeCommit.GetRegion(),
+			Repository:        checkoutOptions.CodeCommit.GetRepository(),
+			SSHKeyID:          checkoutOptions.CodeCommit.GetSshKeyId(),
+			Branch:            checkoutOptions.CodeCommit.GetBranch(),
+			Ref:               checkoutOptions.CodeCommit.GetRef(),
+			CommitID:          checkoutOptions.CodeCommit.GetCommitId(),
+			CommitMessage:     checkoutOptions.CodeCommit.GetCommitMessage(),
+			SparsePaths:       checkoutOptions.CodeCommit.GetSparsePaths(),
+			CloneFilter:       checkoutOptions.CodeCommit.GetCloneFilter(),
+			Submodules:        toSubmoduleOptions(checkoutOptions.CodeCommit.GetSubmodules()),
+			LFS:               toLFSOptions(checkoutOptions.CodeCommit.GetLfs()),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_Svn:
+		securityOptions, err := toSecurityOptions(checkoutOptions.Svn.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.SvnCheckoutOptions{
+			CommitterName:     checkoutOptions.Svn.GetCommitterName(),
+			CommitterUsername: checkoutOptions.Svn.GetCommitterUsername(),
+			Email:             checkoutOptions.Svn.GetEmail(),
+			URL:               checkoutOptions.Svn.GetUrl(),
+			Path:              checkoutOptions.Svn.GetPath(),
+			Revision:          checkoutOptions.Svn.GetRevision(),
+			Username:          checkoutOptions.Svn.GetUsername(),
+			Password:          checkoutOptions.Svn.GetPassword(),
+			CommitMessage:     checkoutOptions.Svn.GetCommitMessage(),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_Fossil:
+		securityOptions, err := toSecurityOptions(checkoutOptions.Fossil.GetSecurityOptions())
+		if err != nil {
+			return nil, err
+		}
+		return &scm.FossilCheckoutOptions{
+			CommitterName:     checkoutOptions.Fossil.GetCommitterName(),
+			CommitterUsername: checkoutOptions.Fossil.GetCommitterUsername(),
+			Email:             checkoutOptions.Fossil.GetEmail(),
+			URL:               checkoutOptions.Fossil.GetUrl(),
+			CheckinID:         checkoutOptions.Fossil.GetCheckinId(),
+			CommitMessage:     checkoutOptions.Fossil.GetCommitMessage(),
+			SecurityOptions:   securityOptions,
+		}, nil
+	case *CheckoutOptions_Local:
+		return &scm.LocalCheckoutOptions{
+			CommitterName:     checkoutOptions.Local.GetCommitterName(),
+			CommitterUsername: checkoutOptions.Local.GetCommitterUsername(),
+			Email:             checkoutOptions.Local.GetEmail(),
+			Path:              checkoutOptions.Local.GetPath(),
+			Branch:            checkoutOptions.Local.GetBranch(),
+			Ref:               checkoutOptions.Local.GetRef(),
+			CommitID:          checkoutOptions.Local.GetCommitId(),
+			CommitMessage:     checkoutOptions.Local.GetCommitMessage(),
+			SparsePaths:       checkoutOptions.Local.GetSparsePaths(),
+			CloneFilter:       checkoutOptions.Local.GetCloneFilter(),
+			Submodules:        toSubmoduleOptions(checkoutOptions.Local.GetSubmodules()),
+			LFS:               toLFSOptions(checkoutOptions.Local.GetLfs()),
+		}, nil
+	case nil:
+		return nil, errorCheckoutOptionsNotSet
+	default:
+		return nil, fmt.Errorf("scmpb: UnknownCheckoutOptions: %T", checkoutOptions)
+	}
+}
+
+func fromSubmoduleOptions(submoduleOptions *scm.SubmoduleOptions) *SubmoduleOptions {
+	if submoduleOptions == nil {
+		return nil
+	}
+	return &SubmoduleOptions{
+		Disabled: submoduleOptions.Disabled,
+		Paths:    submoduleOptions.Paths,
+		Shallow:  submoduleOptions.Shallow,
+		Depth:    int32(submoduleOptions.Depth),
+		Jobs:     int32(submoduleOptions.Jobs),
+	}
+}
+
+func toSubmoduleOptions(protoSubmoduleOptions *SubmoduleOptions) *scm.SubmoduleOptions {
+	if protoSubmoduleOptions == nil {
+		return nil
+	}
+	return &scm.SubmoduleOptions{
+		Disabled: protoSubmoduleOptions.GetDisabled(),
+		Paths:    protoSubmoduleOptions.GetPaths(),
+		Shallow:  protoSubmoduleOptions.GetShallow(),
+		Depth:    int(protoSubmoduleOptions.GetDepth()),
+		Jobs:     int(protoSubmoduleOptions.GetJobs()),
+	}
+}
+
+func fromSecurityOptions(securityOptions scm.SecurityOptions) (*SecurityOptions, error) {
+	if securityOptions == nil {
+		return nil, nil
+	}
+	var protoSecurityOptions *SecurityOptions
+	if err := scm.SecurityOptionsSwitch(
+		securityOptions,
+		func(sshSecurityOptions *scm.SSHSecurityOptions) error {
+			var privateKey []byte
+			if sshSecurityOptions.PrivateKey != nil {
+				data, err := ioutil.ReadAll(sshSecurityOptions.PrivateKey)
+				if err != nil {
+					return err
+				}
+				privateKey = data
+			}
+			protoSecurityOptions = &SecurityOptions{
+				SecurityOptions: &SecurityOptions_Ssh{
+					Ssh: &SSHSecurityOptions{
+						StrictHostKeyChecking: sshSecurityOptions.StrictHostKeyChecking,
+						PrivateKey:            privateKey,
+					},
+				},
+			}
+			return nil
+		},
+		func(accessTokenSecurityOptions *scm.AccessTokenSecurityOptions) error {
+			protoSecurityOptions = &SecurityOptions{
+				SecurityOptions: &SecurityOptions_AccessToken{
+					AccessToken: &AccessTokenSecurityOptions{
+						AccessToken: accessTokenSecurityOptions.AccessToken,
+					},
+				},
+			}
+			return nil
+		},
+		func(awsAccessKeySecurityOptions *scm.AWSAccessKeySecurityOptions) error {
+			protoSecurityOptions = &SecurityOptions{
+				SecurityOptions: &SecurityOptions_AwsAccessKey{
+					AwsAccessKey: &AWSAccessKeySecurityOptions{
+						AccessKeyId:     awsAccessKeySecurityOptions.AccessKeyID,
+						SecretAccessKey: awsAccessKeySecurityOptions.SecretAccessKey,
+						SessionToken:    awsAccessKeySecurityOptions.SessionToken,
+					},
+				},
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+	return protoSecurityOptions, nil
+}
+
+func toSecurityOptions(protoSecurityOptions *SecurityOptions) (scm.SecurityOptions, error) {
+	if protoSecurityOptions == nil {
+		return nil, nil
+	}
+	switch securityOptions := protoSecurityOptions.GetSecurityOptions().(type) {
+	case *SecurityOptions_Ssh:
+		return &scm.SSHSecurityOptions{
+			StrictHostKeyChecking: securityOptions.Ssh.GetStrictHostKeyChecking(),
+			PrivateKey:            bytes.NewBuffer(securityOptions.Ssh.GetPrivateKey()),
+		}, nil
+	case *SecurityOptions_AccessToken:
+		return &scm.AccessTokenSecurityOptions{
+			AccessToken: securityOptions.AccessToken.GetAccessToken(),
+		}, nil
+	case *SecurityOptions_AwsAccessKey:
+		return &scm.AWSAccessKeySecurityOptions{
+			AccessKeyID:     securityOptions.AwsAccessKey.GetAccessKeyId(),
+			SecretAccessKey: securityOptions.AwsAccessKey.GetSecretAccessKey(),
+			SessionToken:    securityOptions.AwsAccessKey.GetSessionToken(),
+		}, nil
+	case nil:
+		return nil, errorSecurityOptionsNotSet
+	default:
+		return nil, fmt.Errorf("scmpb: UnknownSecurityOptions: %T", securityOptions)
+	}
+}
+
+func fromLFSOptions(lfsOptions *scm.LFSOptions) *LFSOptions {
+	if lfsOptions == nil {
+		return nil
+	}
+	return &LFSOptions{
+		Include: lfsOptions.Include,
+		Exclude: lfsOptions.Exclude,
+	}
+}
+
+func toLFSOptions(protoLFSOptions *LFSOptions) *scm.LFSOptions {
+	if protoLFSOptions == nil {
+		return nil
+	}
+	return &scm.LFSOptions{
+		Include: protoLFSOptions.GetInclude(),
+		Exclude: protoLFSOptions.GetExclude(),
+	}
+}
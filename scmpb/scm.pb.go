@@ -0,0 +1,2640 @@
+// The CheckoutOptions and SecurityOptions types of github.com/peter-edge/go-scm.
+// Field names and meanings match the Go structs.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: scm.proto
+
+package scmpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CheckoutOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to CheckoutOptions:
+	//
+	//	*CheckoutOptions_Git
+	//	*CheckoutOptions_Github
+	//	*CheckoutOptions_Hg
+	//	*CheckoutOptions_BitbucketGit
+	//	*CheckoutOptions_BitbucketHg
+	//	*CheckoutOptions_Gitlab
+	//	*CheckoutOptions_Gitea
+	//	*CheckoutOptions_AzureDevOps
+	//	*CheckoutOptions_CodeCommit
+	//	*CheckoutOptions_Svn
+	//	*CheckoutOptions_Fossil
+	//	*CheckoutOptions_Local
+	CheckoutOptions isCheckoutOptions_CheckoutOptions `protobuf_oneof:"checkout_options"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CheckoutOptions) Reset() {
+	*x = CheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutOptions) ProtoMessage() {}
+
+func (x *CheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckoutOptions.ProtoReflect.Descriptor instead.
+func (*CheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckoutOptions) GetCheckoutOptions() isCheckoutOptions_CheckoutOptions {
+	if x != nil {
+		return x.CheckoutOptions
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetGit() *GitCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_Git); ok {
+			return x.Git
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetGithub() *GithubCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_Github); ok {
+			return x.Github
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetHg() *HgCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_Hg); ok {
+			return x.Hg
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetBitbucketGit() *BitbucketGitCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_BitbucketGit); ok {
+			return x.BitbucketGit
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetBitbucketHg() *BitbucketHgCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_BitbucketHg); ok {
+			return x.BitbucketHg
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetGitlab() *GitlabCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_Gitlab); ok {
+			return x.Gitlab
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetGitea() *GiteaCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_Gitea); ok {
+			return x.Gitea
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetAzureDevOps() *AzureDevOpsCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_AzureDevOps); ok {
+			return x.AzureDevOps
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetCodeCommit() *CodeCommitCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_CodeCommit); ok {
+			return x.CodeCommit
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetSvn() *SvnCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_Svn); ok {
+			return x.Svn
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetFossil() *FossilCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_Fossil); ok {
+			return x.Fossil
+		}
+	}
+	return nil
+}
+
+func (x *CheckoutOptions) GetLocal() *LocalCheckoutOptions {
+	if x != nil {
+		if x, ok := x.CheckoutOptions.(*CheckoutOptions_Local); ok {
+			return x.Local
+		}
+	}
+	return nil
+}
+
+type isCheckoutOptions_CheckoutOptions interface {
+	isCheckoutOptions_CheckoutOptions()
+}
+
+type CheckoutOptions_Git struct {
+	Git *GitCheckoutOptions `protobuf:"bytes,1,opt,name=git,proto3,oneof"`
+}
+
+type CheckoutOptions_Github struct {
+	Github *GithubCheckoutOptions `protobuf:"bytes,2,opt,name=github,proto3,oneof"`
+}
+
+type CheckoutOptions_Hg struct {
+	Hg *HgCheckoutOptions `protobuf:"bytes,3,opt,name=hg,proto3,oneof"`
+}
+
+type CheckoutOptions_BitbucketGit struct {
+	BitbucketGit *BitbucketGitCheckoutOptions `protobuf:"bytes,4,opt,name=bitbucket_git,json=bitbucketGit,proto3,oneof"`
+}
+
+type CheckoutOptions_BitbucketHg struct {
+	BitbucketHg *BitbucketHgCheckoutOptions `protobuf:"bytes,5,opt,name=bitbucket_hg,json=bitbucketHg,proto3,oneof"`
+}
+
+type CheckoutOptions_Gitlab struct {
+	Gitlab *GitlabCheckoutOptions `protobuf:"bytes,6,opt,name=gitlab,proto3,oneof"`
+}
+
+type CheckoutOptions_Gitea struct {
+	Gitea *GiteaCheckoutOptions `protobuf:"bytes,7,opt,name=gitea,proto3,oneof"`
+}
+
+type CheckoutOptions_AzureDevOps struct {
+	AzureDevOps *AzureDevOpsCheckoutOptions `protobuf:"bytes,8,opt,name=azure_dev_ops,json=azureDevOps,proto3,oneof"`
+}
+
+type CheckoutOptions_CodeCommit struct {
+	CodeCommit *CodeCommitCheckoutOptions `protobuf:"bytes,9,opt,name=code_commit,json=codeCommit,proto3,oneof"`
+}
+
+type CheckoutOptions_Svn struct {
+	Svn *SvnCheckoutOptions `protobuf:"bytes,10,opt,name=svn,proto3,oneof"`
+}
+
+type CheckoutOptions_Fossil struct {
+	Fossil *FossilCheckoutOptions `protobuf:"bytes,11,opt,name=fossil,proto3,oneof"`
+}
+
+type CheckoutOptions_Local struct {
+	Local *LocalCheckoutOptions `protobuf:"bytes,12,opt,name=local,proto3,oneof"`
+}
+
+func (*CheckoutOptions_Git) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_Github) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_Hg) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_BitbucketGit) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_BitbucketHg) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_Gitlab) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_Gitea) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_AzureDevOps) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_CodeCommit) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_Svn) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_Fossil) isCheckoutOptions_CheckoutOptions() {}
+
+func (*CheckoutOptions_Local) isCheckoutOptions_CheckoutOptions() {}
+
+type SecurityOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to SecurityOptions:
+	//
+	//	*SecurityOptions_Ssh
+	//	*SecurityOptions_AccessToken
+	//	*SecurityOptions_AwsAccessKey
+	SecurityOptions isSecurityOptions_SecurityOptions `protobuf_oneof:"security_options"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SecurityOptions) Reset() {
+	*x = SecurityOptions{}
+	mi := &file_scm_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecurityOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityOptions) ProtoMessage() {}
+
+func (x *SecurityOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityOptions.ProtoReflect.Descriptor instead.
+func (*SecurityOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SecurityOptions) GetSecurityOptions() isSecurityOptions_SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+func (x *SecurityOptions) GetSsh() *SSHSecurityOptions {
+	if x != nil {
+		if x, ok := x.SecurityOptions.(*SecurityOptions_Ssh); ok {
+			return x.Ssh
+		}
+	}
+	return nil
+}
+
+func (x *SecurityOptions) GetAccessToken() *AccessTokenSecurityOptions {
+	if x != nil {
+		if x, ok := x.SecurityOptions.(*SecurityOptions_AccessToken); ok {
+			return x.AccessToken
+		}
+	}
+	return nil
+}
+
+func (x *SecurityOptions) GetAwsAccessKey() *AWSAccessKeySecurityOptions {
+	if x != nil {
+		if x, ok := x.SecurityOptions.(*SecurityOptions_AwsAccessKey); ok {
+			return x.AwsAccessKey
+		}
+	}
+	return nil
+}
+
+type isSecurityOptions_SecurityOptions interface {
+	isSecurityOptions_SecurityOptions()
+}
+
+type SecurityOptions_Ssh struct {
+	Ssh *SSHSecurityOptions `protobuf:"bytes,1,opt,name=ssh,proto3,oneof"`
+}
+
+type SecurityOptions_AccessToken struct {
+	AccessToken *AccessTokenSecurityOptions `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3,oneof"`
+}
+
+type SecurityOptions_AwsAccessKey struct {
+	AwsAccessKey *AWSAccessKeySecurityOptions `protobuf:"bytes,3,opt,name=aws_access_key,json=awsAccessKey,proto3,oneof"`
+}
+
+func (*SecurityOptions_Ssh) isSecurityOptions_SecurityOptions() {}
+
+func (*SecurityOptions_AccessToken) isSecurityOptions_SecurityOptions() {}
+
+func (*SecurityOptions_AwsAccessKey) isSecurityOptions_SecurityOptions() {}
+
+type SSHSecurityOptions struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	StrictHostKeyChecking bool                   `protobuf:"varint,1,opt,name=strict_host_key_checking,json=strictHostKeyChecking,proto3" json:"strict_host_key_checking,omitempty"`
+	PrivateKey            []byte                 `protobuf:"bytes,2,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SSHSecurityOptions) Reset() {
+	*x = SSHSecurityOptions{}
+	mi := &file_scm_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SSHSecurityOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SSHSecurityOptions) ProtoMessage() {}
+
+func (x *SSHSecurityOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SSHSecurityOptions.ProtoReflect.Descriptor instead.
+func (*SSHSecurityOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SSHSecurityOptions) GetStrictHostKeyChecking() bool {
+	if x != nil {
+		return x.StrictHostKeyChecking
+	}
+	return false
+}
+
+func (x *SSHSecurityOptions) GetPrivateKey() []byte {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return nil
+}
+
+type AccessTokenSecurityOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessTokenSecurityOptions) Reset() {
+	*x = AccessTokenSecurityOptions{}
+	mi := &file_scm_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessTokenSecurityOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessTokenSecurityOptions) ProtoMessage() {}
+
+func (x *AccessTokenSecurityOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessTokenSecurityOptions.ProtoReflect.Descriptor instead.
+func (*AccessTokenSecurityOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AccessTokenSecurityOptions) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+type AWSAccessKeySecurityOptions struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccessKeyId     string                 `protobuf:"bytes,1,opt,name=access_key_id,json=accessKeyId,proto3" json:"access_key_id,omitempty"`
+	SecretAccessKey string                 `protobuf:"bytes,2,opt,name=secret_access_key,json=secretAccessKey,proto3" json:"secret_access_key,omitempty"`
+	SessionToken    string                 `protobuf:"bytes,3,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AWSAccessKeySecurityOptions) Reset() {
+	*x = AWSAccessKeySecurityOptions{}
+	mi := &file_scm_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AWSAccessKeySecurityOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AWSAccessKeySecurityOptions) ProtoMessage() {}
+
+func (x *AWSAccessKeySecurityOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AWSAccessKeySecurityOptions.ProtoReflect.Descriptor instead.
+func (*AWSAccessKeySecurityOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AWSAccessKeySecurityOptions) GetAccessKeyId() string {
+	if x != nil {
+		return x.AccessKeyId
+	}
+	return ""
+}
+
+func (x *AWSAccessKeySecurityOptions) GetSecretAccessKey() string {
+	if x != nil {
+		return x.SecretAccessKey
+	}
+	return ""
+}
+
+func (x *AWSAccessKeySecurityOptions) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+type SubmoduleOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Disabled      bool                   `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	Paths         []string               `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	Shallow       bool                   `protobuf:"varint,3,opt,name=shallow,proto3" json:"shallow,omitempty"`
+	Depth         int32                  `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`
+	Jobs          int32                  `protobuf:"varint,5,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmoduleOptions) Reset() {
+	*x = SubmoduleOptions{}
+	mi := &file_scm_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmoduleOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmoduleOptions) ProtoMessage() {}
+
+func (x *SubmoduleOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmoduleOptions.ProtoReflect.Descriptor instead.
+func (*SubmoduleOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubmoduleOptions) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *SubmoduleOptions) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *SubmoduleOptions) GetShallow() bool {
+	if x != nil {
+		return x.Shallow
+	}
+	return false
+}
+
+func (x *SubmoduleOptions) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *SubmoduleOptions) GetJobs() int32 {
+	if x != nil {
+		return x.Jobs
+	}
+	return 0
+}
+
+type LFSOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Include       []string               `protobuf:"bytes,1,rep,name=include,proto3" json:"include,omitempty"`
+	Exclude       []string               `protobuf:"bytes,2,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LFSOptions) Reset() {
+	*x = LFSOptions{}
+	mi := &file_scm_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LFSOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LFSOptions) ProtoMessage() {}
+
+func (x *LFSOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LFSOptions.ProtoReflect.Descriptor instead.
+func (*LFSOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LFSOptions) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *LFSOptions) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+type GitCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	User              string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	CommitterName     string                 `protobuf:"bytes,2,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,3,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Host              string                 `protobuf:"bytes,5,opt,name=host,proto3" json:"host,omitempty"`
+	Path              string                 `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+	Branch            string                 `protobuf:"bytes,7,opt,name=branch,proto3" json:"branch,omitempty"`
+	CommitId          string                 `protobuf:"bytes,8,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,9,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,10,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	SparsePaths       []string               `protobuf:"bytes,11,rep,name=sparse_paths,json=sparsePaths,proto3" json:"sparse_paths,omitempty"`
+	CloneFilter       string                 `protobuf:"bytes,12,opt,name=clone_filter,json=cloneFilter,proto3" json:"clone_filter,omitempty"`
+	Submodules        *SubmoduleOptions      `protobuf:"bytes,13,opt,name=submodules,proto3" json:"submodules,omitempty"`
+	Lfs               *LFSOptions            `protobuf:"bytes,14,opt,name=lfs,proto3" json:"lfs,omitempty"`
+	Ref               string                 `protobuf:"bytes,15,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GitCheckoutOptions) Reset() {
+	*x = GitCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GitCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GitCheckoutOptions) ProtoMessage() {}
+
+func (x *GitCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GitCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*GitCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GitCheckoutOptions) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GitCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *GitCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *GitCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *GitCheckoutOptions) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *GitCheckoutOptions) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GitCheckoutOptions) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *GitCheckoutOptions) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *GitCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *GitCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+func (x *GitCheckoutOptions) GetSparsePaths() []string {
+	if x != nil {
+		return x.SparsePaths
+	}
+	return nil
+}
+
+func (x *GitCheckoutOptions) GetCloneFilter() string {
+	if x != nil {
+		return x.CloneFilter
+	}
+	return ""
+}
+
+func (x *GitCheckoutOptions) GetSubmodules() *SubmoduleOptions {
+	if x != nil {
+		return x.Submodules
+	}
+	return nil
+}
+
+func (x *GitCheckoutOptions) GetLfs() *LFSOptions {
+	if x != nil {
+		return x.Lfs
+	}
+	return nil
+}
+
+func (x *GitCheckoutOptions) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type GithubCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	User              string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	CommitterName     string                 `protobuf:"bytes,2,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,3,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Repository        string                 `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`
+	Branch            string                 `protobuf:"bytes,6,opt,name=branch,proto3" json:"branch,omitempty"`
+	CommitId          string                 `protobuf:"bytes,7,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,8,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,9,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	SparsePaths       []string               `protobuf:"bytes,10,rep,name=sparse_paths,json=sparsePaths,proto3" json:"sparse_paths,omitempty"`
+	CloneFilter       string                 `protobuf:"bytes,11,opt,name=clone_filter,json=cloneFilter,proto3" json:"clone_filter,omitempty"`
+	Submodules        *SubmoduleOptions      `protobuf:"bytes,12,opt,name=submodules,proto3" json:"submodules,omitempty"`
+	Lfs               *LFSOptions            `protobuf:"bytes,13,opt,name=lfs,proto3" json:"lfs,omitempty"`
+	Ref               string                 `protobuf:"bytes,14,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GithubCheckoutOptions) Reset() {
+	*x = GithubCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GithubCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GithubCheckoutOptions) ProtoMessage() {}
+
+func (x *GithubCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GithubCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*GithubCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GithubCheckoutOptions) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GithubCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *GithubCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *GithubCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *GithubCheckoutOptions) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *GithubCheckoutOptions) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *GithubCheckoutOptions) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *GithubCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *GithubCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+func (x *GithubCheckoutOptions) GetSparsePaths() []string {
+	if x != nil {
+		return x.SparsePaths
+	}
+	return nil
+}
+
+func (x *GithubCheckoutOptions) GetCloneFilter() string {
+	if x != nil {
+		return x.CloneFilter
+	}
+	return ""
+}
+
+func (x *GithubCheckoutOptions) GetSubmodules() *SubmoduleOptions {
+	if x != nil {
+		return x.Submodules
+	}
+	return nil
+}
+
+func (x *GithubCheckoutOptions) GetLfs() *LFSOptions {
+	if x != nil {
+		return x.Lfs
+	}
+	return nil
+}
+
+func (x *GithubCheckoutOptions) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type HgCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	User              string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	CommitterName     string                 `protobuf:"bytes,2,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,3,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Host              string                 `protobuf:"bytes,5,opt,name=host,proto3" json:"host,omitempty"`
+	Path              string                 `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+	ChangesetId       string                 `protobuf:"bytes,7,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,8,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,9,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *HgCheckoutOptions) Reset() {
+	*x = HgCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HgCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HgCheckoutOptions) ProtoMessage() {}
+
+func (x *HgCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HgCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*HgCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HgCheckoutOptions) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *HgCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *HgCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *HgCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *HgCheckoutOptions) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *HgCheckoutOptions) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HgCheckoutOptions) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *HgCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *HgCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+type BitbucketGitCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	User              string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	CommitterName     string                 `protobuf:"bytes,2,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,3,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Repository        string                 `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`
+	Branch            string                 `protobuf:"bytes,6,opt,name=branch,proto3" json:"branch,omitempty"`
+	CommitId          string                 `protobuf:"bytes,7,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,8,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,9,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	SparsePaths       []string               `protobuf:"bytes,10,rep,name=sparse_paths,json=sparsePaths,proto3" json:"sparse_paths,omitempty"`
+	CloneFilter       string                 `protobuf:"bytes,11,opt,name=clone_filter,json=cloneFilter,proto3" json:"clone_filter,omitempty"`
+	Submodules        *SubmoduleOptions      `protobuf:"bytes,12,opt,name=submodules,proto3" json:"submodules,omitempty"`
+	Lfs               *LFSOptions            `protobuf:"bytes,13,opt,name=lfs,proto3" json:"lfs,omitempty"`
+	Ref               string                 `protobuf:"bytes,14,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BitbucketGitCheckoutOptions) Reset() {
+	*x = BitbucketGitCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BitbucketGitCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BitbucketGitCheckoutOptions) ProtoMessage() {}
+
+func (x *BitbucketGitCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BitbucketGitCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*BitbucketGitCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BitbucketGitCheckoutOptions) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *BitbucketGitCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *BitbucketGitCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *BitbucketGitCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *BitbucketGitCheckoutOptions) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *BitbucketGitCheckoutOptions) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *BitbucketGitCheckoutOptions) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *BitbucketGitCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *BitbucketGitCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+func (x *BitbucketGitCheckoutOptions) GetSparsePaths() []string {
+	if x != nil {
+		return x.SparsePaths
+	}
+	return nil
+}
+
+func (x *BitbucketGitCheckoutOptions) GetCloneFilter() string {
+	if x != nil {
+		return x.CloneFilter
+	}
+	return ""
+}
+
+func (x *BitbucketGitCheckoutOptions) GetSubmodules() *SubmoduleOptions {
+	if x != nil {
+		return x.Submodules
+	}
+	return nil
+}
+
+func (x *BitbucketGitCheckoutOptions) GetLfs() *LFSOptions {
+	if x != nil {
+		return x.Lfs
+	}
+	return nil
+}
+
+func (x *BitbucketGitCheckoutOptions) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type BitbucketHgCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	User              string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	CommitterName     string                 `protobuf:"bytes,2,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,3,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Repository        string                 `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`
+	ChangesetId       string                 `protobuf:"bytes,6,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,7,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,8,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BitbucketHgCheckoutOptions) Reset() {
+	*x = BitbucketHgCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BitbucketHgCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BitbucketHgCheckoutOptions) ProtoMessage() {}
+
+func (x *BitbucketHgCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BitbucketHgCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*BitbucketHgCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BitbucketHgCheckoutOptions) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *BitbucketHgCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *BitbucketHgCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *BitbucketHgCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *BitbucketHgCheckoutOptions) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *BitbucketHgCheckoutOptions) GetChangesetId() string {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return ""
+}
+
+func (x *BitbucketHgCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *BitbucketHgCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+type GitlabCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	User              string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	CommitterName     string                 `protobuf:"bytes,2,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,3,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Repository        string                 `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`
+	Branch            string                 `protobuf:"bytes,6,opt,name=branch,proto3" json:"branch,omitempty"`
+	CommitId          string                 `protobuf:"bytes,7,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,8,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,9,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	SparsePaths       []string               `protobuf:"bytes,10,rep,name=sparse_paths,json=sparsePaths,proto3" json:"sparse_paths,omitempty"`
+	CloneFilter       string                 `protobuf:"bytes,11,opt,name=clone_filter,json=cloneFilter,proto3" json:"clone_filter,omitempty"`
+	Submodules        *SubmoduleOptions      `protobuf:"bytes,12,opt,name=submodules,proto3" json:"submodules,omitempty"`
+	Lfs               *LFSOptions            `protobuf:"bytes,13,opt,name=lfs,proto3" json:"lfs,omitempty"`
+	Ref               string                 `protobuf:"bytes,14,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GitlabCheckoutOptions) Reset() {
+	*x = GitlabCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GitlabCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GitlabCheckoutOptions) ProtoMessage() {}
+
+func (x *GitlabCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GitlabCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*GitlabCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GitlabCheckoutOptions) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GitlabCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *GitlabCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *GitlabCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *GitlabCheckoutOptions) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *GitlabCheckoutOptions) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *GitlabCheckoutOptions) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *GitlabCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *GitlabCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+func (x *GitlabCheckoutOptions) GetSparsePaths() []string {
+	if x != nil {
+		return x.SparsePaths
+	}
+	return nil
+}
+
+func (x *GitlabCheckoutOptions) GetCloneFilter() string {
+	if x != nil {
+		return x.CloneFilter
+	}
+	return ""
+}
+
+func (x *GitlabCheckoutOptions) GetSubmodules() *SubmoduleOptions {
+	if x != nil {
+		return x.Submodules
+	}
+	return nil
+}
+
+func (x *GitlabCheckoutOptions) GetLfs() *LFSOptions {
+	if x != nil {
+		return x.Lfs
+	}
+	return nil
+}
+
+func (x *GitlabCheckoutOptions) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type GiteaCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	User              string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	CommitterName     string                 `protobuf:"bytes,2,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,3,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Host              string                 `protobuf:"bytes,5,opt,name=host,proto3" json:"host,omitempty"`
+	Repository        string                 `protobuf:"bytes,6,opt,name=repository,proto3" json:"repository,omitempty"`
+	Branch            string                 `protobuf:"bytes,7,opt,name=branch,proto3" json:"branch,omitempty"`
+	CommitId          string                 `protobuf:"bytes,8,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,9,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,10,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	SparsePaths       []string               `protobuf:"bytes,11,rep,name=sparse_paths,json=sparsePaths,proto3" json:"sparse_paths,omitempty"`
+	CloneFilter       string                 `protobuf:"bytes,12,opt,name=clone_filter,json=cloneFilter,proto3" json:"clone_filter,omitempty"`
+	Submodules        *SubmoduleOptions      `protobuf:"bytes,13,opt,name=submodules,proto3" json:"submodules,omitempty"`
+	Lfs               *LFSOptions            `protobuf:"bytes,14,opt,name=lfs,proto3" json:"lfs,omitempty"`
+	Ref               string                 `protobuf:"bytes,15,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GiteaCheckoutOptions) Reset() {
+	*x = GiteaCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GiteaCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GiteaCheckoutOptions) ProtoMessage() {}
+
+func (x *GiteaCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GiteaCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*GiteaCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GiteaCheckoutOptions) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GiteaCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *GiteaCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *GiteaCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *GiteaCheckoutOptions) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *GiteaCheckoutOptions) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *GiteaCheckoutOptions) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *GiteaCheckoutOptions) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *GiteaCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *GiteaCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+func (x *GiteaCheckoutOptions) GetSparsePaths() []string {
+	if x != nil {
+		return x.SparsePaths
+	}
+	return nil
+}
+
+func (x *GiteaCheckoutOptions) GetCloneFilter() string {
+	if x != nil {
+		return x.CloneFilter
+	}
+	return ""
+}
+
+func (x *GiteaCheckoutOptions) GetSubmodules() *SubmoduleOptions {
+	if x != nil {
+		return x.Submodules
+	}
+	return nil
+}
+
+func (x *GiteaCheckoutOptions) GetLfs() *LFSOptions {
+	if x != nil {
+		return x.Lfs
+	}
+	return nil
+}
+
+func (x *GiteaCheckoutOptions) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type AzureDevOpsCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CommitterName     string                 `protobuf:"bytes,1,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,2,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Organization      string                 `protobuf:"bytes,4,opt,name=organization,proto3" json:"organization,omitempty"`
+	Project           string                 `protobuf:"bytes,5,opt,name=project,proto3" json:"project,omitempty"`
+	Repository        string                 `protobuf:"bytes,6,opt,name=repository,proto3" json:"repository,omitempty"`
+	Branch            string                 `protobuf:"bytes,7,opt,name=branch,proto3" json:"branch,omitempty"`
+	CommitId          string                 `protobuf:"bytes,8,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,9,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,10,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	SparsePaths       []string               `protobuf:"bytes,11,rep,name=sparse_paths,json=sparsePaths,proto3" json:"sparse_paths,omitempty"`
+	CloneFilter       string                 `protobuf:"bytes,12,opt,name=clone_filter,json=cloneFilter,proto3" json:"clone_filter,omitempty"`
+	Submodules        *SubmoduleOptions      `protobuf:"bytes,13,opt,name=submodules,proto3" json:"submodules,omitempty"`
+	Lfs               *LFSOptions            `protobuf:"bytes,14,opt,name=lfs,proto3" json:"lfs,omitempty"`
+	Ref               string                 `protobuf:"bytes,15,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AzureDevOpsCheckoutOptions) Reset() {
+	*x = AzureDevOpsCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AzureDevOpsCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AzureDevOpsCheckoutOptions) ProtoMessage() {}
+
+func (x *AzureDevOpsCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AzureDevOpsCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*AzureDevOpsCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetSparsePaths() []string {
+	if x != nil {
+		return x.SparsePaths
+	}
+	return nil
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetCloneFilter() string {
+	if x != nil {
+		return x.CloneFilter
+	}
+	return ""
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetSubmodules() *SubmoduleOptions {
+	if x != nil {
+		return x.Submodules
+	}
+	return nil
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetLfs() *LFSOptions {
+	if x != nil {
+		return x.Lfs
+	}
+	return nil
+}
+
+func (x *AzureDevOpsCheckoutOptions) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type CodeCommitCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CommitterName     string                 `protobuf:"bytes,1,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,2,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Region            string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	Repository        string                 `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`
+	SshKeyId          string                 `protobuf:"bytes,6,opt,name=ssh_key_id,json=sshKeyId,proto3" json:"ssh_key_id,omitempty"`
+	Branch            string                 `protobuf:"bytes,7,opt,name=branch,proto3" json:"branch,omitempty"`
+	CommitId          string                 `protobuf:"bytes,8,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,9,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,10,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	SparsePaths       []string               `protobuf:"bytes,11,rep,name=sparse_paths,json=sparsePaths,proto3" json:"sparse_paths,omitempty"`
+	CloneFilter       string                 `protobuf:"bytes,12,opt,name=clone_filter,json=cloneFilter,proto3" json:"clone_filter,omitempty"`
+	Submodules        *SubmoduleOptions      `protobuf:"bytes,13,opt,name=submodules,proto3" json:"submodules,omitempty"`
+	Lfs               *LFSOptions            `protobuf:"bytes,14,opt,name=lfs,proto3" json:"lfs,omitempty"`
+	Ref               string                 `protobuf:"bytes,15,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CodeCommitCheckoutOptions) Reset() {
+	*x = CodeCommitCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CodeCommitCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CodeCommitCheckoutOptions) ProtoMessage() {}
+
+func (x *CodeCommitCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CodeCommitCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*CodeCommitCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CodeCommitCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *CodeCommitCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *CodeCommitCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CodeCommitCheckoutOptions) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *CodeCommitCheckoutOptions) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *CodeCommitCheckoutOptions) GetSshKeyId() string {
+	if x != nil {
+		return x.SshKeyId
+	}
+	return ""
+}
+
+func (x *CodeCommitCheckoutOptions) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *CodeCommitCheckoutOptions) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *CodeCommitCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *CodeCommitCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+func (x *CodeCommitCheckoutOptions) GetSparsePaths() []string {
+	if x != nil {
+		return x.SparsePaths
+	}
+	return nil
+}
+
+func (x *CodeCommitCheckoutOptions) GetCloneFilter() string {
+	if x != nil {
+		return x.CloneFilter
+	}
+	return ""
+}
+
+func (x *CodeCommitCheckoutOptions) GetSubmodules() *SubmoduleOptions {
+	if x != nil {
+		return x.Submodules
+	}
+	return nil
+}
+
+func (x *CodeCommitCheckoutOptions) GetLfs() *LFSOptions {
+	if x != nil {
+		return x.Lfs
+	}
+	return nil
+}
+
+func (x *CodeCommitCheckoutOptions) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type SvnCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CommitterName     string                 `protobuf:"bytes,1,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,2,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Url               string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Path              string                 `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	Revision          string                 `protobuf:"bytes,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	Username          string                 `protobuf:"bytes,7,opt,name=username,proto3" json:"username,omitempty"`
+	Password          string                 `protobuf:"bytes,8,opt,name=password,proto3" json:"password,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,9,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,10,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SvnCheckoutOptions) Reset() {
+	*x = SvnCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SvnCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SvnCheckoutOptions) ProtoMessage() {}
+
+func (x *SvnCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SvnCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*SvnCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SvnCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *SvnCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *SvnCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SvnCheckoutOptions) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SvnCheckoutOptions) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SvnCheckoutOptions) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *SvnCheckoutOptions) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SvnCheckoutOptions) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *SvnCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *SvnCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+type FossilCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CommitterName     string                 `protobuf:"bytes,1,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,2,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Url               string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	CheckinId         string                 `protobuf:"bytes,5,opt,name=checkin_id,json=checkinId,proto3" json:"checkin_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,6,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SecurityOptions   *SecurityOptions       `protobuf:"bytes,7,opt,name=security_options,json=securityOptions,proto3" json:"security_options,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FossilCheckoutOptions) Reset() {
+	*x = FossilCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FossilCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FossilCheckoutOptions) ProtoMessage() {}
+
+func (x *FossilCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FossilCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*FossilCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FossilCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *FossilCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *FossilCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *FossilCheckoutOptions) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *FossilCheckoutOptions) GetCheckinId() string {
+	if x != nil {
+		return x.CheckinId
+	}
+	return ""
+}
+
+func (x *FossilCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *FossilCheckoutOptions) GetSecurityOptions() *SecurityOptions {
+	if x != nil {
+		return x.SecurityOptions
+	}
+	return nil
+}
+
+type LocalCheckoutOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CommitterName     string                 `protobuf:"bytes,1,opt,name=committer_name,json=committerName,proto3" json:"committer_name,omitempty"`
+	CommitterUsername string                 `protobuf:"bytes,2,opt,name=committer_username,json=committerUsername,proto3" json:"committer_username,omitempty"`
+	Email             string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Path              string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Branch            string                 `protobuf:"bytes,5,opt,name=branch,proto3" json:"branch,omitempty"`
+	CommitId          string                 `protobuf:"bytes,6,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	CommitMessage     string                 `protobuf:"bytes,7,opt,name=commit_message,json=commitMessage,proto3" json:"commit_message,omitempty"`
+	SparsePaths       []string               `protobuf:"bytes,8,rep,name=sparse_paths,json=sparsePaths,proto3" json:"sparse_paths,omitempty"`
+	CloneFilter       string                 `protobuf:"bytes,9,opt,name=clone_filter,json=cloneFilter,proto3" json:"clone_filter,omitempty"`
+	Submodules        *SubmoduleOptions      `protobuf:"bytes,10,opt,name=submodules,proto3" json:"submodules,omitempty"`
+	Lfs               *LFSOptions            `protobuf:"bytes,11,opt,name=lfs,proto3" json:"lfs,omitempty"`
+	Ref               string                 `protobuf:"bytes,12,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *LocalCheckoutOptions) Reset() {
+	*x = LocalCheckoutOptions{}
+	mi := &file_scm_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocalCheckoutOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalCheckoutOptions) ProtoMessage() {}
+
+func (x *LocalCheckoutOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scm_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalCheckoutOptions.ProtoReflect.Descriptor instead.
+func (*LocalCheckoutOptions) Descriptor() ([]byte, []int) {
+	return file_scm_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LocalCheckoutOptions) GetCommitterName() string {
+	if x != nil {
+		return x.CommitterName
+	}
+	return ""
+}
+
+func (x *LocalCheckoutOptions) GetCommitterUsername() string {
+	if x != nil {
+		return x.CommitterUsername
+	}
+	return ""
+}
+
+func (x *LocalCheckoutOptions) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LocalCheckoutOptions) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *LocalCheckoutOptions) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *LocalCheckoutOptions) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *LocalCheckoutOptions) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *LocalCheckoutOptions) GetSparsePaths() []string {
+	if x != nil {
+		return x.SparsePaths
+	}
+	return nil
+}
+
+func (x *LocalCheckoutOptions) GetCloneFilter() string {
+	if x != nil {
+		return x.CloneFilter
+	}
+	return ""
+}
+
+func (x *LocalCheckoutOptions) GetSubmodules() *SubmoduleOptions {
+	if x != nil {
+		return x.Submodules
+	}
+	return nil
+}
+
+func (x *LocalCheckoutOptions) GetLfs() *LFSOptions {
+	if x != nil {
+		return x.Lfs
+	}
+	return nil
+}
+
+func (x *LocalCheckoutOptions) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+var File_scm_proto protoreflect.FileDescriptor
+
+const file_scm_proto_rawDesc = "" +
+	"\n" +
+	"\tscm.proto\x12\x06scm.v1\"\xee\x05\n" +
+	"\x0fCheckoutOptions\x12.\n" +
+	"\x03git\x18\x01 \x01(\v2\x1a.scm.v1.GitCheckoutOptionsH\x00R\x03git\x127\n" +
+	"\x06github\x18\x02 \x01(\v2\x1d.scm.v1.GithubCheckoutOptionsH\x00R\x06github\x12+\n" +
+	"\x02hg\x18\x03 \x01(\v2\x19.scm.v1.HgCheckoutOptionsH\x00R\x02hg\x12J\n" +
+	"\rbitbucket_git\x18\x04 \x01(\v2#.scm.v1.BitbucketGitCheckoutOptionsH\x00R\fbitbucketGit\x12G\n" +
+	"\fbitbucket_hg\x18\x05 \x01(\v2\".scm.v1.BitbucketHgCheckoutOptionsH\x00R\vbitbucketHg\x127\n" +
+	"\x06gitlab\x18\x06 \x01(\v2\x1d.scm.v1.GitlabCheckoutOptionsH\x00R\x06gitlab\x124\n" +
+	"\x05gitea\x18\a \x01(\v2\x1c.scm.v1.GiteaCheckoutOptionsH\x00R\x05gitea\x12H\n" +
+	"\razure_dev_ops\x18\b \x01(\v2\".scm.v1.AzureDevOpsCheckoutOptionsH\x00R\vazureDevOps\x12D\n" +
+	"\vcode_commit\x18\t \x01(\v2!.scm.v1.CodeCommitCheckoutOptionsH\x00R\n" +
+	"codeCommit\x12.\n" +
+	"\x03svn\x18\n" +
+	" \x01(\v2\x1a.scm.v1.SvnCheckoutOptionsH\x00R\x03svn\x127\n" +
+	"\x06fossil\x18\v \x01(\v2\x1d.scm.v1.FossilCheckoutOptionsH\x00R\x06fossil\x124\n" +
+	"\x05local\x18\f \x01(\v2\x1c.scm.v1.LocalCheckoutOptionsH\x00R\x05localB\x12\n" +
+	"\x10checkout_options\"\xeb\x01\n" +
+	"\x0fSecurityOptions\x12.\n" +
+	"\x03ssh\x18\x01 \x01(\v2\x1a.scm.v1.SSHSecurityOptionsH\x00R\x03ssh\x12G\n" +
+	"\faccess_token\x18\x02 \x01(\v2\".scm.v1.AccessTokenSecurityOptionsH\x00R\vaccessToken\x12K\n" +
+	"\x0eaws_access_key\x18\x03 \x01(\v2#.scm.v1.AWSAccessKeySecurityOptionsH\x00R\fawsAccessKeyB\x12\n" +
+	"\x10security_options\"n\n" +
+	"\x12SSHSecurityOptions\x127\n" +
+	"\x18strict_host_key_checking\x18\x01 \x01(\bR\x15strictHostKeyChecking\x12\x1f\n" +
+	"\vprivate_key\x18\x02 \x01(\fR\n" +
+	"privateKey\"?\n" +
+	"\x1aAccessTokenSecurityOptions\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\x92\x01\n" +
+	"\x1bAWSAccessKeySecurityOptions\x12\"\n" +
+	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
+	"\x11secret_access_key\x18\x02 \x01(\tR\x0fsecretAccessKey\x12#\n" +
+	"\rsession_token\x18\x03 \x01(\tR\fsessionToken\"\x88\x01\n" +
+	"\x10SubmoduleOptions\x12\x1a\n" +
+	"\bdisabled\x18\x01 \x01(\bR\bdisabled\x12\x14\n" +
+	"\x05paths\x18\x02 \x03(\tR\x05paths\x12\x18\n" +
+	"\ashallow\x18\x03 \x01(\bR\ashallow\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\x12\x12\n" +
+	"\x04jobs\x18\x05 \x01(\x05R\x04jobs\"@\n" +
+	"\n" +
+	"LFSOptions\x12\x18\n" +
+	"\ainclude\x18\x01 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\x02 \x03(\tR\aexclude\"\x94\x04\n" +
+	"\x12GitCheckoutOptions\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12%\n" +
+	"\x0ecommitter_name\x18\x02 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x03 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
+	"\x04host\x18\x05 \x01(\tR\x04host\x12\x12\n" +
+	"\x04path\x18\x06 \x01(\tR\x04path\x12\x16\n" +
+	"\x06branch\x18\a \x01(\tR\x06branch\x12\x1b\n" +
+	"\tcommit_id\x18\b \x01(\tR\bcommitId\x12%\n" +
+	"\x0ecommit_message\x18\t \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\n" +
+	" \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\x12!\n" +
+	"\fsparse_paths\x18\v \x03(\tR\vsparsePaths\x12!\n" +
+	"\fclone_filter\x18\f \x01(\tR\vcloneFilter\x128\n" +
+	"\n" +
+	"submodules\x18\r \x01(\v2\x18.scm.v1.SubmoduleOptionsR\n" +
+	"submodules\x12$\n" +
+	"\x03lfs\x18\x0e \x01(\v2\x12.scm.v1.LFSOptionsR\x03lfs\x12\x10\n" +
+	"\x03ref\x18\x0f \x01(\tR\x03ref\"\x8f\x04\n" +
+	"\x15GithubCheckoutOptions\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12%\n" +
+	"\x0ecommitter_name\x18\x02 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x03 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x12\x16\n" +
+	"\x06branch\x18\x06 \x01(\tR\x06branch\x12\x1b\n" +
+	"\tcommit_id\x18\a \x01(\tR\bcommitId\x12%\n" +
+	"\x0ecommit_message\x18\b \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\t \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\x12!\n" +
+	"\fsparse_paths\x18\n" +
+	" \x03(\tR\vsparsePaths\x12!\n" +
+	"\fclone_filter\x18\v \x01(\tR\vcloneFilter\x128\n" +
+	"\n" +
+	"submodules\x18\f \x01(\v2\x18.scm.v1.SubmoduleOptionsR\n" +
+	"submodules\x12$\n" +
+	"\x03lfs\x18\r \x01(\v2\x12.scm.v1.LFSOptionsR\x03lfs\x12\x10\n" +
+	"\x03ref\x18\x0e \x01(\tR\x03ref\"\xc9\x02\n" +
+	"\x11HgCheckoutOptions\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12%\n" +
+	"\x0ecommitter_name\x18\x02 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x03 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
+	"\x04host\x18\x05 \x01(\tR\x04host\x12\x12\n" +
+	"\x04path\x18\x06 \x01(\tR\x04path\x12!\n" +
+	"\fchangeset_id\x18\a \x01(\tR\vchangesetId\x12%\n" +
+	"\x0ecommit_message\x18\b \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\t \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\"\x95\x04\n" +
+	"\x1bBitbucketGitCheckoutOptions\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12%\n" +
+	"\x0ecommitter_name\x18\x02 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x03 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x12\x16\n" +
+	"\x06branch\x18\x06 \x01(\tR\x06branch\x12\x1b\n" +
+	"\tcommit_id\x18\a \x01(\tR\bcommitId\x12%\n" +
+	"\x0ecommit_message\x18\b \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\t \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\x12!\n" +
+	"\fsparse_paths\x18\n" +
+	" \x03(\tR\vsparsePaths\x12!\n" +
+	"\fclone_filter\x18\v \x01(\tR\vcloneFilter\x128\n" +
+	"\n" +
+	"submodules\x18\f \x01(\v2\x18.scm.v1.SubmoduleOptionsR\n" +
+	"submodules\x12$\n" +
+	"\x03lfs\x18\r \x01(\v2\x12.scm.v1.LFSOptionsR\x03lfs\x12\x10\n" +
+	"\x03ref\x18\x0e \x01(\tR\x03ref\"\xca\x02\n" +
+	"\x1aBitbucketHgCheckoutOptions\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12%\n" +
+	"\x0ecommitter_name\x18\x02 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x03 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x12!\n" +
+	"\fchangeset_id\x18\x06 \x01(\tR\vchangesetId\x12%\n" +
+	"\x0ecommit_message\x18\a \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\b \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\"\x8f\x04\n" +
+	"\x15GitlabCheckoutOptions\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12%\n" +
+	"\x0ecommitter_name\x18\x02 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x03 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x12\x16\n" +
+	"\x06branch\x18\x06 \x01(\tR\x06branch\x12\x1b\n" +
+	"\tcommit_id\x18\a \x01(\tR\bcommitId\x12%\n" +
+	"\x0ecommit_message\x18\b \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\t \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\x12!\n" +
+	"\fsparse_paths\x18\n" +
+	" \x03(\tR\vsparsePaths\x12!\n" +
+	"\fclone_filter\x18\v \x01(\tR\vcloneFilter\x128\n" +
+	"\n" +
+	"submodules\x18\f \x01(\v2\x18.scm.v1.SubmoduleOptionsR\n" +
+	"submodules\x12$\n" +
+	"\x03lfs\x18\r \x01(\v2\x12.scm.v1.LFSOptionsR\x03lfs\x12\x10\n" +
+	"\x03ref\x18\x0e \x01(\tR\x03ref\"\xa2\x04\n" +
+	"\x14GiteaCheckoutOptions\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12%\n" +
+	"\x0ecommitter_name\x18\x02 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x03 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
+	"\x04host\x18\x05 \x01(\tR\x04host\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x06 \x01(\tR\n" +
+	"repository\x12\x16\n" +
+	"\x06branch\x18\a \x01(\tR\x06branch\x12\x1b\n" +
+	"\tcommit_id\x18\b \x01(\tR\bcommitId\x12%\n" +
+	"\x0ecommit_message\x18\t \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\n" +
+	" \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\x12!\n" +
+	"\fsparse_paths\x18\v \x03(\tR\vsparsePaths\x12!\n" +
+	"\fclone_filter\x18\f \x01(\tR\vcloneFilter\x128\n" +
+	"\n" +
+	"submodules\x18\r \x01(\v2\x18.scm.v1.SubmoduleOptionsR\n" +
+	"submodules\x12$\n" +
+	"\x03lfs\x18\x0e \x01(\v2\x12.scm.v1.LFSOptionsR\x03lfs\x12\x10\n" +
+	"\x03ref\x18\x0f \x01(\tR\x03ref\"\xbe\x04\n" +
+	"\x1aAzureDevOpsCheckoutOptions\x12%\n" +
+	"\x0ecommitter_name\x18\x01 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x02 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\"\n" +
+	"\forganization\x18\x04 \x01(\tR\forganization\x12\x18\n" +
+	"\aproject\x18\x05 \x01(\tR\aproject\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x06 \x01(\tR\n" +
+	"repository\x12\x16\n" +
+	"\x06branch\x18\a \x01(\tR\x06branch\x12\x1b\n" +
+	"\tcommit_id\x18\b \x01(\tR\bcommitId\x12%\n" +
+	"\x0ecommit_message\x18\t \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\n" +
+	" \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\x12!\n" +
+	"\fsparse_paths\x18\v \x03(\tR\vsparsePaths\x12!\n" +
+	"\fclone_filter\x18\f \x01(\tR\vcloneFilter\x128\n" +
+	"\n" +
+	"submodules\x18\r \x01(\v2\x18.scm.v1.SubmoduleOptionsR\n" +
+	"submodules\x12$\n" +
+	"\x03lfs\x18\x0e \x01(\v2\x12.scm.v1.LFSOptionsR\x03lfs\x12\x10\n" +
+	"\x03ref\x18\x0f \x01(\tR\x03ref\"\xb5\x04\n" +
+	"\x19CodeCommitCheckoutOptions\x12%\n" +
+	"\x0ecommitter_name\x18\x01 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x02 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x12\x1c\n" +
+	"\n" +
+	"ssh_key_id\x18\x06 \x01(\tR\bsshKeyId\x12\x16\n" +
+	"\x06branch\x18\a \x01(\tR\x06branch\x12\x1b\n" +
+	"\tcommit_id\x18\b \x01(\tR\bcommitId\x12%\n" +
+	"\x0ecommit_message\x18\t \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\n" +
+	" \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\x12!\n" +
+	"\fsparse_paths\x18\v \x03(\tR\vsparsePaths\x12!\n" +
+	"\fclone_filter\x18\f \x01(\tR\vcloneFilter\x128\n" +
+	"\n" +
+	"submodules\x18\r \x01(\v2\x18.scm.v1.SubmoduleOptionsR\n" +
+	"submodules\x12$\n" +
+	"\x03lfs\x18\x0e \x01(\v2\x12.scm.v1.LFSOptionsR\x03lfs\x12\x10\n" +
+	"\x03ref\x18\x0f \x01(\tR\x03ref\"\xe5\x02\n" +
+	"\x12SvnCheckoutOptions\x12%\n" +
+	"\x0ecommitter_name\x18\x01 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x02 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\x12\x1a\n" +
+	"\brevision\x18\x06 \x01(\tR\brevision\x12\x1a\n" +
+	"\busername\x18\a \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\b \x01(\tR\bpassword\x12%\n" +
+	"\x0ecommit_message\x18\t \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\n" +
+	" \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\"\x9f\x02\n" +
+	"\x15FossilCheckoutOptions\x12%\n" +
+	"\x0ecommitter_name\x18\x01 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x02 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x1d\n" +
+	"\n" +
+	"checkin_id\x18\x05 \x01(\tR\tcheckinId\x12%\n" +
+	"\x0ecommit_message\x18\x06 \x01(\tR\rcommitMessage\x12B\n" +
+	"\x10security_options\x18\a \x01(\v2\x17.scm.v1.SecurityOptionsR\x0fsecurityOptions\"\xaa\x03\n" +
+	"\x14LocalCheckoutOptions\x12%\n" +
+	"\x0ecommitter_name\x18\x01 \x01(\tR\rcommitterName\x12-\n" +
+	"\x12committer_username\x18\x02 \x01(\tR\x11committerUsername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x16\n" +
+	"\x06branch\x18\x05 \x01(\tR\x06branch\x12\x1b\n" +
+	"\tcommit_id\x18\x06 \x01(\tR\bcommitId\x12%\n" +
+	"\x0ecommit_message\x18\a \x01(\tR\rcommitMessage\x12!\n" +
+	"\fsparse_paths\x18\b \x03(\tR\vsparsePaths\x12!\n" +
+	"\fclone_filter\x18\t \x01(\tR\vcloneFilter\x128\n" +
+	"\n" +
+	"submodules\x18\n" +
+	" \x01(\v2\x18.scm.v1.SubmoduleOptionsR\n" +
+	"submodules\x12$\n" +
+	"\x03lfs\x18\v \x01(\v2\x12.scm.v1.LFSOptionsR\x03lfs\x12\x10\n" +
+	"\x03ref\x18\f \x01(\tR\x03refB$Z\"github.com/peter-edge/go-scm/scmpbb\x06proto3"
+
+var (
+	file_scm_proto_rawDescOnce sync.Once
+	file_scm_proto_rawDescData []byte
+)
+
+func file_scm_proto_rawDescGZIP() []byte {
+	file_scm_proto_rawDescOnce.Do(func() {
+		file_scm_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scm_proto_rawDesc), len(file_scm_proto_rawDesc)))
+	})
+	return file_scm_proto_rawDescData
+}
+
+var file_scm_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_scm_proto_goTypes = []any{
+	(*CheckoutOptions)(nil),             // 0: scm.v1.CheckoutOptions
+	(*SecurityOptions)(nil),             // 1: scm.v1.SecurityOptions
+	(*SSHSecurityOptions)(nil),          // 2: scm.v1.SSHSecurityOptions
+	(*AccessTokenSecurityOptions)(nil),  // 3: scm.v1.AccessTokenSecurityOptions
+	(*AWSAccessKeySecurityOptions)(nil), // 4: scm.v1.AWSAccessKeySecurityOptions
+	(*SubmoduleOptions)(nil),            // 5: scm.v1.SubmoduleOptions
+	(*LFSOptions)(nil),                  // 6: scm.v1.LFSOptions
+	(*GitCheckoutOptions)(nil),          // 7: scm.v1.GitCheckoutOptions
+	(*GithubCheckoutOptions)(nil),       // 8: scm.v1.GithubCheckoutOptions
+	(*HgCheckoutOptions)(nil),           // 9: scm.v1.HgCheckoutOptions
+	(*BitbucketGitCheckoutOptions)(nil), // 10: scm.v1.BitbucketGitCheckoutOptions
+	(*BitbucketHgCheckoutOptions)(nil),  // 11: scm.v1.BitbucketHgCheckoutOptions
+	(*GitlabCheckoutOptions)(nil),       // 12: scm.v1.GitlabCheckoutOptions
+	(*GiteaCheckoutOptions)(nil),        // 13: scm.v1.GiteaCheckoutOptions
+	(*AzureDevOpsCheckoutOptions)(nil),  // 14: scm.v1.AzureDevOpsCheckoutOptions
+	(*CodeCommitCheckoutOptions)(nil),   // 15: scm.v1.CodeCommitCheckoutOptions
+	(*SvnCheckoutOptions)(nil),          // 16: scm.v1.SvnCheckoutOptions
+	(*FossilCheckoutOptions)(nil),       // 17: scm.v1.FossilCheckoutOptions
+	(*LocalCheckoutOptions)(nil),        // 18: scm.v1.LocalCheckoutOptions
+}
+var file_scm_proto_depIdxs = []int32{
+	7,  // 0: scm.v1.CheckoutOptions.git:type_name -> scm.v1.GitCheckoutOptions
+	8,  // 1: scm.v1.CheckoutOptions.github:type_name -> scm.v1.GithubCheckoutOptions
+	9,  // 2: scm.v1.CheckoutOptions.hg:type_name -> scm.v1.HgCheckoutOptions
+	10, // 3: scm.v1.CheckoutOptions.bitbucket_git:type_name -> scm.v1.BitbucketGitCheckoutOptions
+	11, // 4: scm.v1.CheckoutOptions.bitbucket_hg:type_name -> scm.v1.BitbucketHgCheckoutOptions
+	12, // 5: scm.v1.CheckoutOptions.gitlab:type_name -> scm.v1.GitlabCheckoutOptions
+	13, // 6: scm.v1.CheckoutOptions.gitea:type_name -> scm.v1.GiteaCheckoutOptions
+	14, // 7: scm.v1.CheckoutOptions.azure_dev_ops:type_name -> scm.v1.AzureDevOpsCheckoutOptions
+	15, // 8: scm.v1.CheckoutOptions.code_commit:type_name -> scm.v1.CodeCommitCheckoutOptions
+	16, // 9: scm.v1.CheckoutOptions.svn:type_name -> scm.v1.SvnCheckoutOptions
+	17, // 10: scm.v1.CheckoutOptions.fossil:type_name -> scm.v1.FossilCheckoutOptions
+	18, // 11: scm.v1.CheckoutOptions.local:type_name -> scm.v1.LocalCheckoutOptions
+	2,  // 12: scm.v1.SecurityOptions.ssh:type_name -> scm.v1.SSHSecurityOptions
+	3,  // 13: scm.v1.SecurityOptions.access_token:type_name -> scm.v1.AccessTokenSecurityOptions
+	4,  // 14: scm.v1.SecurityOptions.aws_access_key:type_name -> scm.v1.AWSAccessKeySecurityOptions
+	1,  // 15: scm.v1.GitCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	5,  // 16: scm.v1.GitCheckoutOptions.submodules:type_name -> scm.v1.SubmoduleOptions
+	6,  // 17: scm.v1.GitCheckoutOptions.lfs:type_name -> scm.v1.LFSOptions
+	1,  // 18: scm.v1.GithubCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	5,  // 19: scm.v1.GithubCheckoutOptions.submodules:type_name -> scm.v1.SubmoduleOptions
+	6,  // 20: scm.v1.GithubCheckoutOptions.lfs:type_name -> scm.v1.LFSOptions
+	1,  // 21: scm.v1.HgCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	1,  // 22: scm.v1.BitbucketGitCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	5,  // 23: scm.v1.BitbucketGitCheckoutOptions.submodules:type_name -> scm.v1.SubmoduleOptions
+	6,  // 24: scm.v1.BitbucketGitCheckoutOptions.lfs:type_name -> scm.v1.LFSOptions
+	1,  // 25: scm.v1.BitbucketHgCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	1,  // 26: scm.v1.GitlabCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	5,  // 27: scm.v1.GitlabCheckoutOptions.submodules:type_name -> scm.v1.SubmoduleOptions
+	6,  // 28: scm.v1.GitlabCheckoutOptions.lfs:type_name -> scm.v1.LFSOptions
+	1,  // 29: scm.v1.GiteaCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	5,  // 30: scm.v1.GiteaCheckoutOptions.submodules:type_name -> scm.v1.SubmoduleOptions
+	6,  // 31: scm.v1.GiteaCheckoutOptions.lfs:type_name -> scm.v1.LFSOptions
+	1,  // 32: scm.v1.AzureDevOpsCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	5,  // 33: scm.v1.AzureDevOpsCheckoutOptions.submodules:type_name -> scm.v1.SubmoduleOptions
+	6,  // 34: scm.v1.AzureDevOpsCheckoutOptions.lfs:type_name -> scm.v1.LFSOptions
+	1,  // 35: scm.v1.CodeCommitCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	5,  // 36: scm.v1.CodeCommitCheckoutOptions.submodules:type_name -> scm.v1.SubmoduleOptions
+	6,  // 37: scm.v1.CodeCommitCheckoutOptions.lfs:type_name -> scm.v1.LFSOptions
+	1,  // 38: scm.v1.SvnCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	1,  // 39: scm.v1.FossilCheckoutOptions.security_options:type_name -> scm.v1.SecurityOptions
+	5,  // 40: scm.v1.LocalCheckoutOptions.submodules:type_name -> scm.v1.SubmoduleOptions
+	6,  // 41: scm.v1.LocalCheckoutOptions.lfs:type_name -> scm.v1.LFSOptions
+	42, // [42:42] is the sub-list for method output_type
+	42, // [42:42] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_scm_proto_init() }
+func file_scm_proto_init() {
+	if File_scm_proto != nil {
+		return
+	}
+	file_scm_proto_msgTypes[0].OneofWrappers = []any{
+		(*CheckoutOptions_Git)(nil),
+		(*CheckoutOptions_Github)(nil),
+		(*CheckoutOptions_Hg)(nil),
+		(*CheckoutOptions_BitbucketGit)(nil),
+		(*CheckoutOptions_BitbucketHg)(nil),
+		(*CheckoutOptions_Gitlab)(nil),
+		(*CheckoutOptions_Gitea)(nil),
+		(*CheckoutOptions_AzureDevOps)(nil),
+		(*CheckoutOptions_CodeCommit)(nil),
+		(*CheckoutOptions_Svn)(nil),
+		(*CheckoutOptions_Fossil)(nil),
+		(*CheckoutOptions_Local)(nil),
+	}
+	file_scm_proto_msgTypes[1].OneofWrappers = []any{
+		(*SecurityOptions_Ssh)(nil),
+		(*SecurityOptions_AccessToken)(nil),
+		(*SecurityOptions_AwsAccessKey)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scm_proto_rawDesc), len(file_scm_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_scm_proto_goTypes,
+		DependencyIndexes: file_scm_proto_depIdxs,
+		MessageInfos:      file_scm_proto_msgTypes,
+	}.Build()
+	File_scm_proto = out.File
+	file_scm_proto_goTypes = nil
+	file_scm_proto_depIdxs = nil
+}
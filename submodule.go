@@ -0,0 +1,143 @@
+package scm
+
+const (
+	envGitSubmoduleUsername = "GO_SCM_SUBMODULE_USERNAME"
+	envGitSubmodulePassword = "GO_SCM_SUBMODULE_PASSWORD"
+	// gitSubmoduleCredentialHelper answers with the credentials in the environment, so they are not on the command line.
+	gitSubmoduleCredentialHelper = `!f() { test "$1" = get && echo "username=${` + envGitSubmoduleUsername + `}" && echo "password=${` + envGitSubmodulePassword + `}"; }; f`
+)
+
+// gitSubmoduleAuth applies the SecurityOptions of a checkout to the submodules on the same host.
+// Submodules with relative URLs already use the URL of the checkout, but absolute URLs do not, and
+// may use SSH where the checkout uses HTTPS or the other way around.
+type gitSubmoduleAuth struct {
+	// configs are name=value settings passed to git submodule with -c, which git passes on to
+	// the commands it runs in the submodules.
+	configs []string
+	// env has the variables read by gitSubmoduleCredentialHelper.
+	env []string
+}
+
+// getGitSubmoduleAuth returns the gitSubmoduleAuth for checkoutOptions, or nil if the submodules
+// are checked out without credentials.
+//
+// Azure Repos uses different paths for SSH and HTTPS, so its URLs are not rewritten, and CodeCommit
+// access keys sign a password for each repository, so they cannot be used for submodules.
+func getGitSubmoduleAuth(checkoutOptions CheckoutOptions) (*gitSubmoduleAuth, error) {
+	var submoduleAuth *gitSubmoduleAuth
+	if err := CheckoutOptionsSwitch(
+		checkoutOptions,
+		func(gitCheckoutOptions *GitCheckoutOptions) error {
+			if gitCheckoutOptions.SecurityOptions != nil && gitCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeSsh {
+				submoduleAuth = newSSHGitSubmoduleAuth(joinStrings(gitCheckoutOptions.User, "@", gitCheckoutOptions.Host, ":"), gitCheckoutOptions.Host)
+			}
+			return nil
+		},
+		func(githubCheckoutOptions *GithubCheckoutOptions) error {
+			submoduleAuth = getHostGitSubmoduleAuth(githubCheckoutOptions.SecurityOptions, "github.com")
+			return nil
+		},
+		func(hgCheckoutOptions *HgCheckoutOptions) error {
+			return nil
+		},
+		func(bitbucketGitCheckoutOptions *BitbucketGitCheckoutOptions) error {
+			submoduleAuth = getHostGitSubmoduleAuth(bitbucketGitCheckoutOptions.SecurityOptions, "bitbucket.org")
+			return nil
+		},
+		func(bitbucketHgCheckoutOptions *BitbucketHgCheckoutOptions) error {
+			return nil
+		},
+		func(gitlabCheckoutOptions *GitlabCheckoutOptions) error {
+			submoduleAuth = getHostGitSubmoduleAuth(gitlabCheckoutOptions.SecurityOptions, "gitlab.com")
+			return nil
+		},
+		func(giteaCheckoutOptions *GiteaCheckoutOptions) error {
+			submoduleAuth = getHostGitSubmoduleAuth(giteaCheckoutOptions.SecurityOptions, giteaCheckoutOptions.Host)
+			return nil
+		},
+		func(azureDevOpsCheckoutOptions *AzureDevOpsCheckoutOptions) error {
+			if azureDevOpsCheckoutOptions.SecurityOptions != nil && azureDevOpsCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeAccessToken {
+				submoduleAuth = newHTTPSGitSubmoduleAuth(
+					"dev.azure.com",
+					azureDevOpsCheckoutOptions.Organization,
+					(azureDevOpsCheckoutOptions.SecurityOptions.(*AccessTokenSecurityOptions)).AccessToken,
+				)
+			}
+			return nil
+		},
+		func(codeCommitCheckoutOptions *CodeCommitCheckoutOptions) error {
+			if codeCommitCheckoutOptions.SecurityOptions != nil && codeCommitCheckoutOptions.SecurityOptions.Type() == SecurityOptionsTypeSsh {
+				host := getCodeCommitHost(codeCommitCheckoutOptions.Region)
+				submoduleAuth = newSSHGitSubmoduleAuth(joinStrings("ssh://", codeCommitCheckoutOptions.SSHKeyID, "@", host, "/"), host)
+			}
+			return nil
+		},
+		func(svnCheckoutOptions *SvnCheckoutOptions) error {
+			return nil
+		},
+		func(fossilCheckoutOptions *FossilCheckoutOptions) error {
+			return nil
+		},
+		func(localCheckoutOptions *LocalCheckoutOptions) error {
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+	return submoduleAuth, nil
+}
+
+// getHostGitSubmoduleAuth is for the providers that use git@host: for SSH, and an access token
+// as the basic auth user with x-oauth-basic as the password for HTTPS.
+func getHostGitSubmoduleAuth(securityOptions SecurityOptions, host string) *gitSubmoduleAuth {
+	if securityOptions == nil {
+		return nil
+	}
+	switch securityOptions.Type() {
+	case SecurityOptionsTypeSsh:
+		return newSSHGitSubmoduleAuth(joinStrings("git@", host, ":"), host)
+	case SecurityOptionsTypeAccessToken:
+		return newHTTPSGitSubmoduleAuth(
+			host,
+			(securityOptions.(*AccessTokenSecurityOptions)).AccessToken,
+			"x-oauth-basic",
+			joinStrings("git@", host, ":"),
+			joinStrings("ssh://git@", host, "/"),
+		)
+	default:
+		return nil
+	}
+}
+
+// newSSHGitSubmoduleAuth rewrites the HTTPS and git:// URLs of host to sshBase, so that GIT_SSH_COMMAND
+// applies to them.
+func newSSHGitSubmoduleAuth(sshBase string, host string) *gitSubmoduleAuth {
+	return &gitSubmoduleAuth{
+		configs: []string{
+			joinStrings("url.", sshBase, ".insteadOf=https://", host, "/"),
+			joinStrings("url.", sshBase, ".insteadOf=git://", host, "/"),
+		},
+	}
+}
+
+// newHTTPSGitSubmoduleAuth rewrites the sshBases and git:// URLs of host to HTTPS, and answers
+// requests for credentials for host with username and password.
+func newHTTPSGitSubmoduleAuth(host string, username string, password string, sshBases ...string) *gitSubmoduleAuth {
+	var configs []string
+	for _, base := range append(sshBases, joinStrings("git://", host, "/")) {
+		configs = append(configs, joinStrings("url.https://", host, "/.insteadOf=", base))
+	}
+	configs = append(
+		configs,
+		// an empty helper clears the helpers configured on the host, so they are not asked first
+		joinStrings("credential.https://", host, ".helper="),
+		joinStrings("credential.https://", host, ".helper=", gitSubmoduleCredentialHelper),
+	)
+	return &gitSubmoduleAuth{
+		configs: configs,
+		env: []string{
+			joinStrings(envGitSubmoduleUsername, "=", username),
+			joinStrings(envGitSubmodulePassword, "=", password),
+		},
+	}
+}
@@ -0,0 +1,164 @@
+package scm
+
+import (
+	"bytes"
+	"os"
+	osexec "os/exec"
+	"strings"
+	"testing"
+)
+
+func TestGitSubmoduleAuth(t *testing.T) {
+	t.Parallel()
+	for _, testCase := range []struct {
+		checkoutOptions CheckoutOptions
+		// expectedURLs maps submodule URLs to the URLs git uses for them
+		expectedURLs map[string]string
+		// host is asked for credentials if set, which are expected to be expectedCredentials
+		host                string
+		expectedCredentials string
+	}{
+		{
+			checkoutOptions: &GithubCheckoutOptions{
+				User:            "peter-edge",
+				Repository:      "smartystreets_ruby",
+				Branch:          "master",
+				CommitID:        testSmartystreetsCommitID,
+				SecurityOptions: &AccessTokenSecurityOptions{AccessToken: "token"},
+			},
+			expectedURLs: map[string]string{
+				"git@github.com:peter-edge/private.git":       "https://github.com/peter-edge/private.git",
+				"ssh://git@github.com/peter-edge/private.git": "https://github.com/peter-edge/private.git",
+				"git://github.com/peter-edge/private.git":     "https://github.com/peter-edge/private.git",
+				"https://github.com/peter-edge/private.git":   "https://github.com/peter-edge/private.git",
+				"git@gitlab.com:peter-edge/private.git":       "git@gitlab.com:peter-edge/private.git",
+			},
+			host:                "github.com",
+			expectedCredentials: "username=token\npassword=x-oauth-basic",
+		},
+		{
+			checkoutOptions: &GithubCheckoutOptions{
+				User:            "peter-edge",
+				Repository:      "smartystreets_ruby",
+				Branch:          "master",
+				CommitID:        testSmartystreetsCommitID,
+				SecurityOptions: &SSHSecurityOptions{},
+			},
+			expectedURLs: map[string]string{
+				"https://github.com/peter-edge/private.git": "git@github.com:peter-edge/private.git",
+				"git://github.com/peter-edge/private.git":   "git@github.com:peter-edge/private.git",
+				"git@github.com:peter-edge/private.git":     "git@github.com:peter-edge/private.git",
+				"https://gitlab.com/peter-edge/private.git": "https://gitlab.com/peter-edge/private.git",
+			},
+		},
+		{
+			checkoutOptions: &GiteaCheckoutOptions{
+				User:            "codeship",
+				Host:            "gitea.example.com",
+				Repository:      "banana",
+				Branch:          "master",
+				CommitID:        testBananaCommitID,
+				SecurityOptions: &AccessTokenSecurityOptions{AccessToken: "token"},
+			},
+			expectedURLs: map[string]string{
+				"git@gitea.example.com:codeship/private.git": "https://gitea.example.com/codeship/private.git",
+				"git@github.com:codeship/private.git":        "git@github.com:codeship/private.git",
+			},
+			host:                "gitea.example.com",
+			expectedCredentials: "username=token\npassword=x-oauth-basic",
+		},
+		{
+			checkoutOptions: &AzureDevOpsCheckoutOptions{
+				Organization:    "codeship",
+				Project:         "fruit",
+				Repository:      "banana",
+				Branch:          "master",
+				CommitID:        testBananaCommitID,
+				SecurityOptions: &AccessTokenSecurityOptions{AccessToken: "token"},
+			},
+			expectedURLs: map[string]string{
+				"https://dev.azure.com/codeship/fruit/_git/private": "https://dev.azure.com/codeship/fruit/_git/private",
+			},
+			host:                "dev.azure.com",
+			expectedCredentials: "username=codeship\npassword=token",
+		},
+		{
+			checkoutOptions: &CodeCommitCheckoutOptions{
+				Region:          "us-east-1",
+				Repository:      "banana",
+				SSHKeyID:        "APKAEIBAERJR2EXAMPLE",
+				Branch:          "master",
+				CommitID:        testBananaCommitID,
+				SecurityOptions: &SSHSecurityOptions{},
+			},
+			expectedURLs: map[string]string{
+				"https://git-codecommit.us-east-1.amazonaws.com/v1/repos/private": "ssh://APKAEIBAERJR2EXAMPLE@git-codecommit.us-east-1.amazonaws.com/v1/repos/private",
+			},
+		},
+	} {
+		submoduleAuth, err := getGitSubmoduleAuth(testCase.checkoutOptions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if submoduleAuth == nil {
+			t.Fatalf("%T: expected submodule auth", testCase.checkoutOptions)
+		}
+		for submoduleURL, expectedURL := range testCase.expectedURLs {
+			actualURL := runTestGitWithSubmoduleAuth(t, submoduleAuth, "", "ls-remote", "--get-url", submoduleURL)
+			if actualURL != expectedURL {
+				t.Errorf("%T: %s: expected %s, got %s", testCase.checkoutOptions, submoduleURL, expectedURL, actualURL)
+			}
+		}
+		if testCase.host != "" {
+			credentials := runTestGitWithSubmoduleAuth(t, submoduleAuth, "protocol=https\nhost="+testCase.host+"\n\n", "credential", "fill")
+			if !strings.Contains(credentials, testCase.expectedCredentials) {
+				t.Errorf("%T: expected %q, got %q", testCase.checkoutOptions, testCase.expectedCredentials, credentials)
+			}
+			if strings.Contains(strings.Join(submoduleAuth.configs, " "), "token") {
+				t.Errorf("%T: expected the access token to not be in the git config, got %v", testCase.checkoutOptions, submoduleAuth.configs)
+			}
+		}
+	}
+}
+
+func TestGitSubmoduleAuthNotSet(t *testing.T) {
+	t.Parallel()
+	for _, checkoutOptions := range []CheckoutOptions{
+		&GithubCheckoutOptions{User: "peter-edge", Repository: "smartystreets_ruby"},
+		&HgCheckoutOptions{User: "hg", Host: "bitbucket.org", Path: "/durin42/hg-git"},
+		&LocalCheckoutOptions{Path: "/srv/git/banana.git"},
+		&CodeCommitCheckoutOptions{
+			Region:          "us-east-1",
+			Repository:      "banana",
+			SecurityOptions: &AWSAccessKeySecurityOptions{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"},
+		},
+	} {
+		submoduleAuth, err := getGitSubmoduleAuth(checkoutOptions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if submoduleAuth != nil {
+			t.Errorf("%T: expected no submodule auth, got %+v", checkoutOptions, submoduleAuth)
+		}
+	}
+}
+
+// runTestGitWithSubmoduleAuth runs git with the settings and environment of submoduleAuth outside of a repository.
+func runTestGitWithSubmoduleAuth(t *testing.T, submoduleAuth *gitSubmoduleAuth, stdin string, args ...string) string {
+	var gitArgs []string
+	for _, config := range submoduleAuth.configs {
+		gitArgs = append(gitArgs, "-c", config)
+	}
+	cmd := osexec.Command("git", append(gitArgs, args...)...)
+	cmd.Dir = getTempDir(t)
+	cmd.Env = append(append(os.Environ(), "GIT_TERMINAL_PROMPT=0"), submoduleAuth.env...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("%v: %v %v", args, err, stderr.String())
+	}
+	return strings.TrimSpace(stdout.String())
+}